@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+// buildResult is printed by build and push.
+type buildResult struct {
+	Reference string `json:"reference,omitempty"`
+	Layout    string `json:"layout,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	Digest    string `json:"digest"`
+}
+
+func (r buildResult) Table() ([]string, [][]string) {
+	reference := r.Reference
+	if reference == "" {
+		reference = r.Layout + ":" + r.Tag
+	}
+	return []string{"REFERENCE", "DIGEST"}, [][]string{{reference, r.Digest}}
+}
+
+var buildFlags = []cli.Flag{
+	&cli.StringFlag{Name: "version", Usage: "runtime `VERSION`, defaults to the version field of the spec"},
+	&cli.StringFlag{Name: "spec-version", Usage: "spec format `VERSION`"},
+	&cli.StringFlag{Name: "description", Usage: "artifact description annotation"},
+	&cli.StringFlag{Name: "source", Usage: "artifact source `URL` annotation"},
+	&cli.StringSliceFlag{Name: "annotation", Usage: "additional manifest annotation `KEY=VALUE`"},
+}
+
+// buildOptions reads the build flags. The created time honours
+// SOURCE_DATE_EPOCH.
+func buildOptions(c *cli.Context) (artifact.BuildOptions, error) {
+	opts := artifact.BuildOptions{
+		Version:        c.String("spec-version"),
+		RuntimeVersion: c.String("version"),
+		Description:    c.String("description"),
+		Source:         c.String("source"),
+	}
+
+	for _, a := range c.StringSlice("annotation") {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok || key == "" {
+			return opts, fmt.Errorf("invalid annotation %q: expected KEY=VALUE", a)
+		}
+		if opts.Annotations == nil {
+			opts.Annotations = make(map[string]string)
+		}
+		opts.Annotations[key] = value
+	}
+
+	created, err := createdTime()
+	if err != nil {
+		return opts, err
+	}
+	opts.CreatedTime = created
+	return opts, nil
+}
+
+// readSpecFile reads and validates the spec file at path.
+func readSpecFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	runtimeSpec, err := spec.ParseYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := spec.ValidateRuntimeSpec(runtimeSpec); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return data, nil
+}
+
+func (e *env) buildCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "build",
+		Usage:     "Build an artifact from a spec into a local OCI layout",
+		ArgsUsage: "<spec-file>",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{Name: "layout", Usage: "OCI layout `DIR` to write the artifact to", Required: true},
+			&cli.StringFlag{Name: "tag", Usage: "`TAG` of the artifact in the layout", Value: "latest"},
+		}, buildFlags...),
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected a spec file")
+			}
+
+			specContent, err := readSpecFile(c.Args().First())
+			if err != nil {
+				return err
+			}
+			opts, err := buildOptions(c)
+			if err != nil {
+				return err
+			}
+
+			store, desc, err := artifact.Build(c.Context, specContent, opts)
+			if err != nil {
+				return err
+			}
+			layout, err := oci.New(c.String("layout"))
+			if err != nil {
+				return fmt.Errorf("failed to open layout: %w", err)
+			}
+			if err := artifact.Push(c.Context, store, desc, layout, c.String("tag"), oras.DefaultCopyGraphOptions); err != nil {
+				return err
+			}
+
+			result := buildResult{Layout: c.String("layout"), Tag: c.String("tag"), Digest: string(desc.Digest)}
+			return e.print(c, result, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Built %s:%s\nDigest: %s\n", result.Layout, result.Tag, result.Digest)
+				return err
+			})
+		},
+	}
+}
+
+func (e *env) pushCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "push",
+		Usage:     "Build an artifact from a spec and push it to a registry",
+		ArgsUsage: "<spec-file> <reference>",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{Name: "policy", Usage: "refuse to push a spec that violates the admission policy in `FILE`"},
+		}, buildFlags...),
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 2 {
+				return fmt.Errorf("expected a spec file and a reference")
+			}
+
+			pushClient := e.client
+			if path := c.String("policy"); path != "" {
+				policy, err := spec.LoadAdmissionPolicy(path)
+				if err != nil {
+					return err
+				}
+				opts := e.clientOpts
+				opts.AdmissionPolicy = policy
+				pushClient = client.NewClient(opts)
+			}
+
+			specContent, err := readSpecFile(c.Args().Get(0))
+			if err != nil {
+				return err
+			}
+			opts, err := buildOptions(c)
+			if err != nil {
+				return err
+			}
+
+			reference := c.Args().Get(1)
+			digest, err := pushClient.Push(c.Context, specContent, opts, reference)
+			if err != nil {
+				return err
+			}
+
+			result := buildResult{Reference: reference, Digest: digest}
+			return e.print(c, result, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Pushed %s\nDigest: %s\n", result.Reference, result.Digest)
+				return err
+			})
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// diffResult is printed by diff.
+type diffResult struct {
+	Old      string         `json:"old"`
+	New      string         `json:"new"`
+	Breaking bool           `json:"breaking"`
+	Changes  spec.ChangeSet `json:"changes"`
+}
+
+func (r diffResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Changes))
+	for _, c := range r.Changes {
+		rows = append(rows, []string{string(c.Type), c.Path, strconv.FormatBool(c.Breaking)})
+	}
+	return []string{"TYPE", "PATH", "BREAKING"}, rows
+}
+
+func (e *env) diffCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "diff",
+		Usage:     "Show the changes between two specs, given as files or references",
+		ArgsUsage: "<old> <new>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "fail-on-breaking", Usage: "exit with status 1 if any change is breaking"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 2 {
+				return fmt.Errorf("expected two specs")
+			}
+
+			oldSpec, err := e.loadSpec(c, c.Args().Get(0))
+			if err != nil {
+				return err
+			}
+			newSpec, err := e.loadSpec(c, c.Args().Get(1))
+			if err != nil {
+				return err
+			}
+
+			changes := spec.Diff(oldSpec, newSpec)
+			if changes == nil {
+				changes = spec.ChangeSet{}
+			}
+			result := diffResult{Old: c.Args().Get(0), New: c.Args().Get(1), Breaking: changes.IsBreaking(), Changes: changes}
+
+			err = e.print(c, result, func(w io.Writer) error {
+				_, err := io.WriteString(w, changes.String())
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			if result.Breaking && c.Bool("fail-on-breaking") {
+				return cli.Exit("", 1)
+			}
+			return nil
+		},
+	}
+}
+
+// loadSpec reads a spec from a file, or pulls it if there is no such file
+// and arg is an artifact reference.
+func (e *env) loadSpec(c *cli.Context, arg string) (*common.RuntimeSpec, error) {
+	data, err := os.ReadFile(arg)
+	if err == nil {
+		return spec.ParseYAML(data)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if _, refErr := registry.ParseReference(arg); refErr != nil {
+		return nil, err
+	}
+
+	data, pullErr := e.client.FetchSpec(c.Context, arg)
+	if pullErr != nil {
+		return nil, fmt.Errorf("%w; %w", err, pullErr)
+	}
+	return spec.ParseYAML(data)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+// initResult is printed by init.
+type initResult struct {
+	File string              `json:"file,omitempty"`
+	Spec *common.RuntimeSpec `json:"spec"`
+}
+
+func (r initResult) Table() ([]string, [][]string) {
+	names := make([]string, 0, len(r.Spec.Spec))
+	for name := range r.Spec.Spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		comp := r.Spec.Spec[name]
+		rows = append(rows, []string{name, comp.Registry, comp.Digest, strconv.FormatBool(comp.Resources != nil && comp.Resources.TEEEnabled)})
+	}
+	return []string{"COMPONENT", "REGISTRY", "DIGEST", "TEE"}, rows
+}
+
+func (e *env) initCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "init",
+		Usage:     "Generate a starter spec, printing it or writing it to a file",
+		ArgsUsage: "[<spec-file>]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "name", Usage: "runtime `NAME`", Required: true},
+			&cli.StringFlag{Name: "version", Usage: "runtime `VERSION`", Value: spec.DefaultScaffoldVersion},
+			&cli.StringFlag{Name: "kind", Usage: "spec `KIND`", Value: common.DefaultKind},
+			&cli.StringSliceFlag{Name: "component", Usage: "add a component `NAME[=IMAGE]`, with IMAGE given by tag or digest"},
+			&cli.BoolFlag{Name: "tee", Usage: "enable a TEE for every component"},
+			&cli.BoolFlag{Name: "resolve", Usage: "pin images given by tag to their current digest in the registry"},
+			&cli.BoolFlag{Name: "force", Usage: "overwrite an existing spec file"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() > 1 {
+				return fmt.Errorf("expected at most one spec file")
+			}
+
+			opts := spec.ScaffoldOptions{
+				Name:    c.String("name"),
+				Version: c.String("version"),
+				Kind:    c.String("kind"),
+				TEE:     c.Bool("tee"),
+			}
+			for _, comp := range c.StringSlice("component") {
+				name, image, ok := strings.Cut(comp, "=")
+				opts.Components = append(opts.Components, name)
+				if ok {
+					if opts.Images == nil {
+						opts.Images = make(map[string]string)
+					}
+					opts.Images[name] = image
+				}
+			}
+			if c.Bool("resolve") {
+				opts.Resolve = func(ctx context.Context, reference string) (string, error) {
+					desc, err := e.client.Resolve(ctx, reference)
+					return string(desc.Digest), err
+				}
+			}
+
+			runtimeSpec, err := spec.Scaffold(c.Context, opts)
+			if err != nil {
+				return err
+			}
+			specContent, err := spec.ToYAML(runtimeSpec)
+			if err != nil {
+				return err
+			}
+			if err := spec.CheckPinned(runtimeSpec); err != nil {
+				fmt.Fprintf(c.App.ErrWriter, "warning: %v; pin every image before pushing\n", err)
+			}
+
+			result := initResult{File: c.Args().First(), Spec: runtimeSpec}
+			if result.File != "" {
+				flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+				if !c.Bool("force") {
+					flags |= os.O_EXCL
+				}
+				f, err := os.OpenFile(result.File, flags, 0644)
+				if errors.Is(err, os.ErrExist) {
+					return fmt.Errorf("%s already exists: use --force to overwrite it", result.File)
+				}
+				if err != nil {
+					return fmt.Errorf("failed to write spec: %w", err)
+				}
+				if _, err := f.Write(specContent); err != nil {
+					f.Close()
+					return fmt.Errorf("failed to write spec: %w", err)
+				}
+				if err := f.Close(); err != nil {
+					return fmt.Errorf("failed to write spec: %w", err)
+				}
+			}
+
+			return e.print(c, result, func(w io.Writer) error {
+				if result.File == "" {
+					_, err := w.Write(specContent)
+					return err
+				}
+				_, err := fmt.Fprintf(w, "Spec written to %s\n", result.File)
+				return err
+			})
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/output"
+	"github.com/urfave/cli/v2"
+)
+
+// inspectResult is printed by inspect. As a table it lists the components.
+type inspectResult struct {
+	*client.ArtifactInfo
+}
+
+func (r inspectResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Components))
+	for _, comp := range r.Components {
+		required := strings.Join(comp.RequiredEnv, ",")
+		if required == "" {
+			required = "-"
+		}
+		rows = append(rows, []string{comp.Name, comp.Registry, comp.Digest, fmt.Sprint(comp.TEEEnabled), required})
+	}
+	return []string{"NAME", "REGISTRY", "DIGEST", "TEE", "REQUIRED ENV"}, rows
+}
+
+func (r inspectResult) text(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Reference:\t%s\n", r.Reference)
+	fmt.Fprintf(tw, "Digest:\t%s\n", r.Digest)
+	fmt.Fprintf(tw, "Media type:\t%s\n", r.MediaType)
+	if r.ArtifactType != "" {
+		fmt.Fprintf(tw, "Artifact type:\t%s\n", r.ArtifactType)
+	}
+	fmt.Fprintf(tw, "Size:\t%d bytes\n", r.Size)
+	fmt.Fprintf(tw, "Name:\t%s\n", r.Name)
+	for _, field := range []struct{ label, value string }{
+		{"Version:", r.Version},
+		{"Spec version:", r.SpecVersion},
+		{"Created:", r.Created},
+		{"Source:", r.Source},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(tw, "%s\t%s\n", field.label, field.value)
+		}
+	}
+
+	if len(r.Config) > 0 {
+		fmt.Fprintf(tw, "Config:\t%s\n", r.Config)
+	}
+
+	keys := make([]string, 0, len(r.Annotations))
+	for k := range r.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		fmt.Fprintln(tw, "Annotations:")
+		for _, k := range keys {
+			fmt.Fprintf(tw, "  %s\t%s\n", k, r.Annotations[k])
+		}
+	}
+
+	fmt.Fprintln(tw, "Layers:")
+	for _, l := range r.Layers {
+		fmt.Fprintf(tw, "  %s\t%s\t%d bytes\n", l.MediaType, l.Digest, l.Size)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "Components:")
+	return output.Table{}.Format(w, r)
+}
+
+func (e *env) inspectCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "inspect",
+		Usage:     "Show the manifest, config and components of an artifact without downloading component images",
+		ArgsUsage: "<reference>",
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected a reference")
+			}
+
+			info, err := e.client.Inspect(c.Context, c.Args().First())
+			if err != nil {
+				return err
+			}
+
+			result := inspectResult{info}
+			return e.print(c, result, result.text)
+		},
+	}
+}
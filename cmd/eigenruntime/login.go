@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// loginResult is printed by login and logout.
+type loginResult struct {
+	Registry string `json:"registry"`
+	Username string `json:"username,omitempty"`
+}
+
+func (r loginResult) Table() ([]string, [][]string) {
+	return []string{"REGISTRY", "USERNAME"}, [][]string{{r.Registry, r.Username}}
+}
+
+func (e *env) loginCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "login",
+		Usage:     "Check credentials with a registry and save them for later commands",
+		ArgsUsage: "<registry>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "username", Aliases: []string{"u"}, Usage: "registry `USERNAME`"},
+			&cli.StringFlag{Name: "password", Aliases: []string{"p"}, Usage: "registry `PASSWORD`; prefer --password-stdin"},
+			&cli.BoolFlag{Name: "password-stdin", Usage: "read the password from stdin"},
+			&cli.BoolFlag{Name: "identity-token", Usage: "treat the password as an identity token and ignore the username"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected a registry")
+			}
+
+			password := c.String("password")
+			if c.Bool("password-stdin") {
+				if password != "" {
+					return fmt.Errorf("--password and --password-stdin are mutually exclusive")
+				}
+				data, err := io.ReadAll(c.App.Reader)
+				if err != nil {
+					return fmt.Errorf("failed to read password: %w", err)
+				}
+				password = strings.TrimRight(string(data), "\r\n")
+			}
+			if password == "" {
+				return fmt.Errorf("a password is required: use --password-stdin")
+			}
+
+			result := loginResult{Registry: c.Args().First()}
+			cred := auth.Credential{RefreshToken: password}
+			if !c.Bool("identity-token") {
+				if c.String("username") == "" {
+					return fmt.Errorf("a username is required: use --username")
+				}
+				result.Username = c.String("username")
+				cred = auth.Credential{Username: result.Username, Password: password}
+			}
+
+			if err := e.client.Login(c.Context, result.Registry, cred); err != nil {
+				return err
+			}
+
+			return e.print(c, result, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Logged in to %s\n", result.Registry)
+				return err
+			})
+		},
+	}
+}
+
+func (e *env) logoutCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "logout",
+		Usage:     "Remove the saved credentials for a registry",
+		ArgsUsage: "<registry>",
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected a registry")
+			}
+
+			result := loginResult{Registry: c.Args().First()}
+			if err := e.client.Logout(c.Context, result.Registry); err != nil {
+				return err
+			}
+
+			return e.print(c, result, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Removed credentials for %s\n", result.Registry)
+				return err
+			})
+		},
+	}
+}
@@ -0,0 +1,177 @@
+// Command eigenruntime builds, publishes and inspects EigenRuntime artifacts
+// and runs plugins that deploy them.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/credentials"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/output"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/plugin"
+	"github.com/urfave/cli/v2"
+)
+
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+func main() {
+	plugins, err := plugin.Discover(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	app, err := newApp(os.Stdout, os.Stderr, plugins...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// env holds the state shared by all commands, set up from the global flags
+// before a command runs.
+type env struct {
+	client     *client.Client
+	clientOpts client.ClientOptions
+	logger     *slog.Logger
+	host       *plugin.Host
+
+	// formatter renders results, or is nil for each command's text output.
+	formatter output.Formatter
+}
+
+func newApp(stdout, stderr io.Writer, plugins ...plugin.EigenRuntimePlugin) (*cli.App, error) {
+	e := &env{host: plugin.NewHost(plugin.HostOptions{})}
+	if err := e.host.Register(plugins...); err != nil {
+		return nil, err
+	}
+	pluginCommands, err := e.host.Commands()
+	if err != nil {
+		return nil, err
+	}
+
+	app := &cli.App{
+		Name:                 "eigenruntime",
+		Usage:                "Build, publish and deploy EigenRuntime artifacts",
+		Version:              version,
+		Writer:               stdout,
+		ErrWriter:            stderr,
+		EnableBashCompletion: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "registry-config",
+				Usage:   "load per-registry settings (plain HTTP, TLS, mirrors) from `FILE`",
+				EnvVars: []string{plugin.RegistryConfigEnv},
+			},
+			&cli.BoolFlag{
+				Name:    "plain-http",
+				Usage:   "talk to registries over HTTP instead of HTTPS",
+				EnvVars: []string{plugin.PlainHTTPEnv},
+			},
+			&cli.StringFlag{
+				Name:    "cache-dir",
+				Usage:   "cache pulled artifacts in `DIR`",
+				EnvVars: []string{plugin.CacheDirEnv},
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "output `FORMAT`: text, json, yaml, table or template=<go-template>",
+				Value:   "text",
+			},
+			&cli.BoolFlag{
+				Name:  "verbose",
+				Usage: "log registry requests to stderr",
+			},
+		},
+		Before: e.setup,
+		Commands: append([]*cli.Command{
+			e.initCommand(),
+			e.buildCommand(),
+			e.pushCommand(),
+			e.pullCommand(),
+			e.inspectCommand(),
+			e.validateCommand(),
+			e.diffCommand(),
+			e.tagCommand(),
+			e.loginCommand(),
+			e.logoutCommand(),
+		}, pluginCommands...),
+	}
+	return app, nil
+}
+
+func (e *env) setup(c *cli.Context) error {
+	level := slog.LevelWarn
+	if c.Bool("verbose") {
+		level = slog.LevelDebug
+	}
+	e.logger = slog.New(slog.NewTextHandler(c.App.ErrWriter, &slog.HandlerOptions{Level: level}))
+
+	pluginOutput := output.Formatter(output.Text{})
+	e.formatter = nil
+	if format := c.String("output"); format != "text" {
+		f, err := output.Parse(format)
+		if err != nil {
+			return err
+		}
+		e.formatter, pluginOutput = f, f
+	}
+
+	opts := client.ClientOptions{
+		PlainHTTP:   c.Bool("plain-http"),
+		CacheDir:    c.String("cache-dir"),
+		Credentials: credentials.Default(),
+		Logger:      e.logger,
+	}
+	if path := c.String("registry-config"); path != "" {
+		if err := client.LoadRegistryConfig(path, &opts); err != nil {
+			return err
+		}
+	}
+	e.client, e.clientOpts = client.NewClient(opts), opts
+
+	e.host.Configure(plugin.HostOptions{
+		Client:         e.client,
+		Logger:         e.logger,
+		Output:         pluginOutput,
+		OutputFormat:   c.String("output"),
+		PlainHTTP:      opts.PlainHTTP,
+		RegistryConfig: c.String("registry-config"),
+		CacheDir:       opts.CacheDir,
+	})
+	return nil
+}
+
+// print writes v with the selected output format, or with text when the
+// format is text.
+func (e *env) print(c *cli.Context, v any, text func(w io.Writer) error) error {
+	if e.formatter == nil {
+		return text(c.App.Writer)
+	}
+	return e.formatter.Format(c.App.Writer, v)
+}
+
+// createdTime honours SOURCE_DATE_EPOCH for reproducible builds.
+func createdTime() (*time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+	}
+	t := time.Unix(seconds, 0).UTC()
+	return &t, nil
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/plugin"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var columnPadding = regexp.MustCompile(` {2,}`)
+
+// run runs the CLI with args and returns its output and exit code. The
+// registry host and any temporary directory are replaced by stable
+// placeholders so the output can be compared with golden files.
+func run(t *testing.T, reg *registrytest.Registry, args ...string) (string, int) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	app, err := newApp(&stdout, &stderr, &testPlugin{})
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	app.ExitErrHandler = func(*cli.Context, error) {}
+
+	code := 0
+	if err := app.Run(append([]string{"eigenruntime", "--plain-http"}, args...)); err != nil {
+		var exitErr cli.ExitCoder
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("Failed to run %v: %v\n%s", args, err, stderr.String())
+		}
+		code = exitErr.ExitCode()
+	}
+
+	out := strings.ReplaceAll(stdout.String(), reg.Host(), "REGISTRY")
+	return out, code
+}
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(expected) {
+		t.Errorf("Output does not match %s:\n--- expected\n%s\n--- got\n%s", path, expected, got)
+	}
+}
+
+type testPlugin struct{}
+
+func (testPlugin) Name() string                     { return "example" }
+func (testPlugin) Version() string                  { return "1.0.0" }
+func (testPlugin) DescribeCommands() []*cli.Command { return nil }
+func (testPlugin) GetCommands() []*cli.Command      { return nil }
+func (testPlugin) RemoveCommands() []*cli.Command   { return nil }
+
+func (testPlugin) Capabilities() plugin.Capabilities {
+	return plugin.Capabilities{APIVersions: "^1", Specs: []plugin.SpecType{{Kind: "Runtime"}}}
+}
+
+func (testPlugin) RunCommands() []*cli.Command {
+	return []*cli.Command{{
+		Name: "run",
+		Action: plugin.Action(func(ctx *plugin.Context) error {
+			return ctx.Print(map[string]string{"running": ctx.Spec.Name, "digest": ctx.Digest})
+		}),
+	}}
+}
+
+func TestCommands(t *testing.T) {
+	reg := registrytest.New(t)
+	t.Setenv("SOURCE_DATE_EPOCH", "1704067200")
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	ref := reg.Host() + "/example/runtime:v1.0.0"
+	layout := t.TempDir()
+
+	// Seed the registry with the artifact the read-only commands work on.
+	if _, code := run(t, reg, "push", "testdata/spec.yaml", ref); code != 0 {
+		t.Fatalf("Failed to push seed artifact: exit code %d", code)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		exitCode int
+	}{
+		{name: "push", args: []string{"push", "--description", "Example runtime", "testdata/spec.yaml", reg.Host() + "/example/runtime:v1.0.1"}},
+		{name: "push-json", args: []string{"-o", "json", "push", "testdata/spec.yaml", ref}},
+		{name: "build", args: []string{"build", "--layout", layout, "--tag", "v1.0.0", "testdata/spec.yaml"}},
+		{name: "pull", args: []string{"pull", ref}},
+		{name: "pull-json", args: []string{"-o", "json", "pull", ref}},
+		{name: "inspect", args: []string{"inspect", ref}},
+		{name: "inspect-json", args: []string{"--output", "json", "inspect", ref}},
+		{name: "validate", args: []string{"validate", "testdata/spec.yaml", "testdata/invalid.yaml"}, exitCode: 1},
+		{name: "validate-policy-json", args: []string{"-o", "json", "validate", "--policy", "testdata/policy.yaml", "testdata/spec.yaml"}, exitCode: 1},
+		{name: "diff", args: []string{"diff", ref, "testdata/spec-v2.yaml"}},
+		{name: "diff-breaking", args: []string{"-o", "json", "diff", "--fail-on-breaking", "testdata/spec.yaml", "testdata/spec-v2.yaml"}, exitCode: 1},
+		{name: "tag", args: []string{"tag", ref, "stable", "latest"}},
+		{name: "plugins-list", args: []string{"plugins", "list"}},
+		{name: "run-routed", args: []string{"run", ref}},
+		{name: "run-routed-json", args: []string{"-o", "json", "run", ref}},
+		{name: "pull-yaml", args: []string{"-o", "yaml", "pull", ref}},
+		{name: "pull-table", args: []string{"-o", "table", "pull", ref}},
+		{name: "inspect-template", args: []string{"-o", `template={{.digest}} {{range .layers}}{{.mediaType}} {{end}}{{"\n"}}`, "inspect", ref}},
+		{name: "validate-table", args: []string{"-o", "table", "validate", "testdata/spec.yaml", "testdata/invalid.yaml"}, exitCode: 1},
+		{name: "diff-table", args: []string{"-o", "table", "diff", "testdata/spec.yaml", "testdata/spec-v2.yaml"}},
+		{name: "inspect-table", args: []string{"-o", "table", "inspect", ref}},
+		{name: "init", args: []string{"init", "--name", "my-avs", "--component", "aggregator", "--component", "performer", "--tee"}},
+		{name: "init-resolve-table", args: []string{"-o", "table", "init", "--name", "my-avs", "--component", "performer=" + ref, "--resolve"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, code := run(t, reg, tt.args...)
+			if strings.HasSuffix(tt.name, "-table") {
+				// Column widths depend on the length of the registry host.
+				out = columnPadding.ReplaceAllString(out, "  ")
+			}
+			if code != tt.exitCode {
+				t.Errorf("Expected exit code %d, got %d", tt.exitCode, code)
+			}
+			assertGolden(t, tt.name, strings.ReplaceAll(out, layout, "LAYOUT"))
+		})
+	}
+
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+	if _, code := run(t, reg, "init", "--name", "my-avs", specFile); code != 0 {
+		t.Fatalf("Failed to write scaffolded spec: exit code %d", code)
+	}
+	if out, code := run(t, reg, "validate", specFile); code != 0 {
+		t.Errorf("Expected scaffolded spec to be valid, got %s", out)
+	}
+
+	out, _ := run(t, reg, "-o", "json", "pull", reg.Host()+"/example/runtime:stable")
+	if !strings.Contains(out, `"name": "example-runtime"`) {
+		t.Errorf("Expected tag to be pullable, got %s", out)
+	}
+}
+
+func TestRegistryConfig(t *testing.T) {
+	reg := registrytest.New(t)
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	config := filepath.Join(t.TempDir(), "registries.yaml")
+	data := "registries:\n  " + reg.Host() + ":\n    plainHTTP: true\n"
+	if err := os.WriteFile(config, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write registry config: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	app, err := newApp(&stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+	// Without the config the client would use HTTPS and fail.
+	err = app.Run([]string{"eigenruntime", "--registry-config", config, "push", "testdata/spec.yaml", reg.Host() + "/example/runtime:v1"})
+	if err != nil {
+		t.Fatalf("Failed to push with registry config: %v\n%s", err, stderr.String())
+	}
+
+	if err := app.Run([]string{"eigenruntime", "-o", "xml", "tag", "a", "b"}); err == nil || !strings.Contains(err.Error(), "unsupported output format") {
+		t.Errorf("Expected unsupported output format error, got %v", err)
+	}
+	if err := app.Run([]string{"eigenruntime", "-o", "table", "diff", "testdata/spec.yaml", "testdata/spec.yaml"}); err != nil {
+		t.Errorf("Expected an empty table for no changes, got %v", err)
+	}
+	err = app.Run([]string{"eigenruntime", "-o", "template={{index .changes 5}}", "diff", "testdata/spec.yaml", "testdata/spec.yaml"})
+	if err == nil || !strings.Contains(err.Error(), `template: output:1:2: executing "output"`) {
+		t.Errorf("Expected template execution error, got %v", err)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	reg := registrytest.New(t)
+	reg.RequireAuth("user", "pass")
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	ref := reg.Host() + "/example/runtime:v1.0.0"
+
+	var stdout, stderr bytes.Buffer
+	app, err := newApp(&stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	if err := app.Run([]string{"eigenruntime", "--plain-http", "push", "testdata/spec.yaml", ref}); !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("Expected push without credentials to be unauthorized, got %v", err)
+	}
+
+	app.Reader = strings.NewReader("wrong\n")
+	err = app.Run([]string{"eigenruntime", "--plain-http", "login", "-u", "user", "--password-stdin", reg.Host()})
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Fatalf("Expected login with a wrong password to be unauthorized, got %v", err)
+	}
+
+	app.Reader = strings.NewReader("pass\n")
+	if err := app.Run([]string{"eigenruntime", "--plain-http", "login", "-u", "user", "--password-stdin", reg.Host()}); err != nil {
+		t.Fatalf("Failed to log in: %v\n%s", err, stderr.String())
+	}
+	assertGolden(t, "login", strings.ReplaceAll(stdout.String(), reg.Host(), "REGISTRY"))
+
+	// Later commands pick up the saved credentials.
+	if _, code := run(t, reg, "push", "testdata/spec.yaml", ref); code != 0 {
+		t.Fatalf("Failed to push after login: exit code %d", code)
+	}
+	if _, code := run(t, reg, "pull", ref); code != 0 {
+		t.Fatalf("Failed to pull after login: exit code %d", code)
+	}
+
+	out, _ := run(t, reg, "-o", "json", "logout", reg.Host())
+	assertGolden(t, "logout-json", out)
+
+	stdout.Reset()
+	if err := app.Run([]string{"eigenruntime", "--plain-http", "pull", ref}); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("Expected pull after logout to be unauthorized, got %v", err)
+	}
+}
+
+func TestAdmissionPolicy(t *testing.T) {
+	reg := registrytest.New(t)
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	ref := reg.Host() + "/example/runtime:v1.0.0"
+
+	var stdout, stderr bytes.Buffer
+	app, err := newApp(&stdout, &stderr, &testPlugin{})
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	var validationErr *spec.ValidationError
+	err = app.Run([]string{"eigenruntime", "--plain-http", "push", "--policy", "testdata/policy.yaml", "testdata/spec.yaml", ref})
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected push to be rejected by the policy, got %v", err)
+	}
+
+	if err := app.Run([]string{"eigenruntime", "--plain-http", "push", "testdata/spec.yaml", ref}); err != nil {
+		t.Fatalf("Failed to push without a policy: %v\n%s", err, stderr.String())
+	}
+	if err := app.Run([]string{"eigenruntime", "--plain-http", "run", "--policy", "testdata/policy.yaml", ref}); !errors.As(err, &validationErr) {
+		t.Errorf("Expected run to be rejected by the policy, got %v", err)
+	}
+	if strings.Contains(stdout.String(), "running") {
+		t.Errorf("Expected the plugin not to run, got %s", stdout.String())
+	}
+}
+
+func TestInitPlaceholderDigest(t *testing.T) {
+	reg := registrytest.New(t)
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	specFile := filepath.Join(t.TempDir(), "spec.yaml")
+
+	var stdout, stderr bytes.Buffer
+	app, err := newApp(&stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	if err := app.Run([]string{"eigenruntime", "init", "--name", "my-avs", specFile}); err != nil {
+		t.Fatalf("Failed to write scaffolded spec: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "warning: ") || !strings.Contains(stderr.String(), "spec.performer.digest") {
+		t.Errorf("Expected a warning about the placeholder digest, got %q", stderr.String())
+	}
+
+	var validationErr *spec.ValidationError
+	err = app.Run([]string{"eigenruntime", "--plain-http", "push", specFile, reg.Host() + "/example/runtime:v0.1.0"})
+	if !errors.As(err, &validationErr) {
+		t.Errorf("Expected push of the scaffolded spec to be refused, got %v", err)
+	}
+}
+
+func TestDiffLoadSpec(t *testing.T) {
+	reg := registrytest.New(t)
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	app, err := newApp(&stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Failed to create app: %v", err)
+	}
+
+	// Neither is an artifact reference, so nothing is pulled.
+	err = app.Run([]string{"eigenruntime", "--plain-http", "diff", "testdata/missing.yaml", "testdata/spec.yaml"})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+	err = app.Run([]string{"eigenruntime", "--plain-http", "diff", "testdata", "testdata/spec.yaml"})
+	if err == nil || errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the read error of the directory, got %v", err)
+	}
+	if len(reg.Requests()) != 0 {
+		t.Errorf("Expected nothing to be pulled, got %v", reg.Requests())
+	}
+
+	ref := reg.Host() + "/example/missing:v1"
+	err = app.Run([]string{"eigenruntime", "--plain-http", "diff", ref, "testdata/spec.yaml"})
+	if !errors.Is(err, os.ErrNotExist) || !strings.Contains(err.Error(), "example/missing") {
+		t.Errorf("Expected both the file and the pull error, got %v", err)
+	}
+}
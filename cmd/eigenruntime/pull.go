@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+// pullResult is printed by pull.
+type pullResult struct {
+	Reference string              `json:"reference"`
+	Digest    string              `json:"digest"`
+	File      string              `json:"file,omitempty"`
+	Spec      *common.RuntimeSpec `json:"spec"`
+}
+
+func (r pullResult) Table() ([]string, [][]string) {
+	return []string{"REFERENCE", "NAME", "VERSION", "DIGEST"},
+		[][]string{{r.Reference, r.Spec.Name, r.Spec.Version, r.Digest}}
+}
+
+func (e *env) pullCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "pull",
+		Usage:     "Pull an artifact and print or save its spec",
+		ArgsUsage: "<reference>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "file", Aliases: []string{"f"}, Usage: "write the spec to `FILE`"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return fmt.Errorf("expected a reference")
+			}
+
+			reference := c.Args().First()
+			art, err := e.client.Pull(c.Context, reference)
+			if err != nil {
+				return err
+			}
+			if len(art.Layers) == 0 {
+				return fmt.Errorf("no spec layer found in artifact")
+			}
+			specContent := art.Layers[0].Content
+
+			runtimeSpec, err := spec.ParseYAML(specContent)
+			if err != nil {
+				return err
+			}
+
+			result := pullResult{Reference: reference, Digest: art.Digest, File: c.String("file"), Spec: runtimeSpec}
+			if result.File != "" {
+				if err := os.WriteFile(result.File, specContent, 0644); err != nil {
+					return fmt.Errorf("failed to write spec: %w", err)
+				}
+			}
+
+			return e.print(c, result, func(w io.Writer) error {
+				if result.File == "" {
+					_, err := w.Write(specContent)
+					return err
+				}
+				_, err := fmt.Fprintf(w, "Pulled %s\nDigest: %s\nSpec written to %s\n", reference, result.Digest, result.File)
+				return err
+			})
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// tagResult is printed by tag.
+type tagResult struct {
+	Reference string   `json:"reference"`
+	Tags      []string `json:"tags"`
+}
+
+func (r tagResult) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(r.Tags))
+	for _, tag := range r.Tags {
+		rows = append(rows, []string{r.Reference, tag})
+	}
+	return []string{"SOURCE", "TAG"}, rows
+}
+
+func (e *env) tagCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "tag",
+		Usage:     "Add tags to an artifact without copying its content",
+		ArgsUsage: "<reference> <tag>...",
+		Action: func(c *cli.Context) error {
+			if c.NArg() < 2 {
+				return fmt.Errorf("expected a reference and at least one tag")
+			}
+
+			result := tagResult{Reference: c.Args().First(), Tags: c.Args().Tail()}
+			if err := e.client.Tag(c.Context, result.Reference, result.Tags...); err != nil {
+				return err
+			}
+
+			return e.print(c, result, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "Tagged %s as %s\n", result.Reference, strings.Join(result.Tags, ", "))
+				return err
+			})
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+// validateResult is printed by validate for each spec file.
+type validateResult struct {
+	File       string           `json:"file"`
+	Valid      bool             `json:"valid"`
+	Violations []spec.Violation `json:"violations,omitempty"`
+}
+
+// validateResults is printed by validate.
+type validateResults []validateResult
+
+func (rs validateResults) Table() ([]string, [][]string) {
+	rows := make([][]string, 0, len(rs))
+	for _, r := range rs {
+		violations := make([]string, len(r.Violations))
+		for i, v := range r.Violations {
+			violations[i] = v.String()
+		}
+		rows = append(rows, []string{r.File, strconv.FormatBool(r.Valid), strings.Join(violations, "; ")})
+	}
+	return []string{"FILE", "VALID", "VIOLATIONS"}, rows
+}
+
+func (e *env) validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "validate",
+		Usage:     "Validate spec files, optionally against an admission policy",
+		ArgsUsage: "<spec-file>...",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "policy", Usage: "also check the admission policy in `FILE`"},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return fmt.Errorf("expected at least one spec file")
+			}
+
+			var policy *spec.AdmissionPolicy
+			if path := c.String("policy"); path != "" {
+				var err error
+				if policy, err = spec.LoadAdmissionPolicy(path); err != nil {
+					return err
+				}
+			}
+
+			results := make(validateResults, 0, c.NArg())
+			valid := true
+			for _, path := range c.Args().Slice() {
+				result, err := validateFile(path, policy)
+				if err != nil {
+					return err
+				}
+				valid = valid && result.Valid
+				results = append(results, result)
+			}
+
+			err := e.print(c, results, func(w io.Writer) error {
+				for _, r := range results {
+					if r.Valid {
+						fmt.Fprintf(w, "%s: valid\n", r.File)
+						continue
+					}
+					fmt.Fprintf(w, "%s: invalid\n", r.File)
+					for _, v := range r.Violations {
+						fmt.Fprintf(w, "  %s\n", v)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return cli.Exit("", 1)
+			}
+			return nil
+		},
+	}
+}
+
+func validateFile(path string, policy *spec.AdmissionPolicy) (validateResult, error) {
+	result := validateResult{File: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read spec file: %w", err)
+	}
+	runtimeSpec, err := spec.ParseYAML(data)
+	if err != nil {
+		result.Violations = []spec.Violation{{Message: err.Error()}}
+		return result, nil
+	}
+
+	errs := []error{spec.ValidateRuntimeSpec(runtimeSpec)}
+	if policy != nil {
+		errs = append(errs, policy.Check(runtimeSpec))
+	}
+	for _, err := range errs {
+		var verr *spec.ValidationError
+		if errors.As(err, &verr) {
+			result.Violations = append(result.Violations, verr.Violations...)
+		} else if err != nil {
+			return result, err
+		}
+	}
+
+	result.Valid = len(result.Violations) == 0
+	return result, nil
+}
@@ -1,10 +1,15 @@
 module github.com/Layr-Labs/eigenruntime-go
 
-go 1.21
+go 1.21.0
 
 require (
+	github.com/Masterminds/semver/v3 v3.3.1
+	github.com/gofrs/flock v0.12.1
 	github.com/opencontainers/go-digest v1.0.0
 	github.com/opencontainers/image-spec v1.1.0
+	github.com/urfave/cli/v2 v2.27.7
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
 	gopkg.in/yaml.v3 v3.0.1
 	oras.land/oras-go/v2 v2.3.1
 )
@@ -12,7 +17,7 @@ require (
 require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.7 // indirect
 	github.com/russross/blackfriday/v2 v2.1.0 // indirect
-	github.com/urfave/cli/v2 v2.27.7 // indirect
 	github.com/xrash/smetrics v0.0.0-20240521201337-686a1a2994c1 // indirect
 	golang.org/x/sync v0.4.0 // indirect
+	golang.org/x/sys v0.22.0 // indirect
 )
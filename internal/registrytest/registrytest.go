@@ -0,0 +1,445 @@
+// Package registrytest provides an in-memory OCI registry for tests.
+package registrytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/manifest"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// SpecYAML is the spec stored by PutRuntime. It takes the registry host and
+// the performer image digest as format arguments.
+const SpecYAML = `apiVersion: eigenruntime.io/v1alpha1
+kind: Runtime
+name: example-runtime
+version: v1.0.0
+spec:
+  performer:
+    registry: %s/example/performer
+    digest: %s
+    env:
+      - name: "env_var"
+        type: "secret"
+        required: true
+`
+
+// Token is the bearer token issued by the token endpoint of a registry that
+// requires authentication.
+const Token = "test-token"
+
+// Registry is a minimal in-memory implementation of the OCI distribution
+// API, sufficient for exercising clients against a real HTTP server.
+type Registry struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	repos    map[string]*repo
+	uploads  int
+	requests []string
+
+	// deleteDisabled makes manifest deletion fail the way registries with
+	// deletion turned off do.
+	deleteDisabled bool
+	// failures are served in order, one per request, before the registry
+	// resumes answering normally.
+	failures []Failure
+	// username and password, when set, are required by a token endpoint at
+	// /token, whose token every other request must then present.
+	username, password string
+}
+
+// Failure is an error response served by FailNext.
+type Failure struct {
+	Status     int
+	RetryAfter string
+}
+
+type repo struct {
+	blobs     map[digest.Digest][]byte
+	manifests map[digest.Digest]storedManifest
+	tags      map[string]digest.Digest
+}
+
+type storedManifest struct {
+	mediaType string
+	content   []byte
+}
+
+// New starts a registry that is closed when the test ends.
+func New(t testing.TB) *Registry {
+	t.Helper()
+
+	reg := NewUnstarted(t)
+	reg.Start()
+	return reg
+}
+
+// NewUnstarted returns a registry whose server can be configured, e.g. for
+// TLS, before it is started.
+func NewUnstarted(t testing.TB) *Registry {
+	t.Helper()
+
+	reg := &Registry{
+		repos: make(map[string]*repo),
+	}
+	reg.Server = httptest.NewUnstartedServer(reg)
+	t.Cleanup(reg.Close)
+	return reg
+}
+
+// Host returns the host:port of the registry, suitable for use in references.
+func (reg *Registry) Host() string {
+	u, err := url.Parse(reg.URL)
+	if err != nil {
+		panic(err)
+	}
+	return u.Host
+}
+
+// Requests returns the method and path of every request served so far.
+func (reg *Registry) Requests() []string {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	return append([]string(nil), reg.requests...)
+}
+
+// SetDeleteDisabled makes manifest deletion fail, or succeed again.
+func (reg *Registry) SetDeleteDisabled(disabled bool) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.deleteDisabled = disabled
+}
+
+// RequireAuth makes the registry require a bearer token, issued by its
+// token endpoint in exchange for username and password.
+func (reg *Registry) RequireAuth(username, password string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.username, reg.password = username, password
+}
+
+// FailNext makes the next requests fail with the given responses.
+func (reg *Registry) FailNext(failures ...Failure) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.failures = append(reg.failures, failures...)
+}
+
+func (reg *Registry) repo(name string) *repo {
+	r, ok := reg.repos[name]
+	if !ok {
+		r = &repo{
+			blobs:     make(map[digest.Digest][]byte),
+			manifests: make(map[digest.Digest]storedManifest),
+			tags:      make(map[string]digest.Digest),
+		}
+		reg.repos[name] = r
+	}
+	return r
+}
+
+// PutBlob stores content in repo and returns its descriptor.
+func (reg *Registry) PutBlob(repo string, content []byte) ocispec.Descriptor {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	d := digest.FromBytes(content)
+	reg.repo(repo).blobs[d] = content
+	return ocispec.Descriptor{Digest: d, Size: int64(len(content))}
+}
+
+// CorruptBlob replaces the stored content of a blob while keeping it
+// addressed by its original digest.
+func (reg *Registry) CorruptBlob(repo string, d digest.Digest, content []byte) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.repo(repo).blobs[d] = content
+}
+
+// PutManifest stores a manifest in repo, tagging it unless tag is empty.
+func (reg *Registry) PutManifest(repo, tag, mediaType string, content []byte) ocispec.Descriptor {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	d := digest.FromBytes(content)
+	r := reg.repo(repo)
+	r.manifests[d] = storedManifest{mediaType: mediaType, content: content}
+	if tag != "" {
+		r.tags[tag] = d
+	}
+	return ocispec.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(content))}
+}
+
+// PutImage stores a single-layer container image and returns its manifest descriptor.
+func (reg *Registry) PutImage(t testing.TB, repo string, layer []byte) ocispec.Descriptor {
+	t.Helper()
+
+	config := reg.PutBlob(repo, []byte("{}"))
+	config.MediaType = ocispec.MediaTypeImageConfig
+	layerDesc := reg.PutBlob(repo, layer)
+	layerDesc.MediaType = ocispec.MediaTypeImageLayer
+
+	m := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispec.Descriptor{layerDesc},
+	}
+	m.SchemaVersion = 2
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Failed to marshal image manifest: %v", err)
+	}
+	return reg.PutManifest(repo, "", ocispec.MediaTypeImageManifest, data)
+}
+
+// PutArtifact stores an EigenRuntime artifact built from specContent under tag.
+func (reg *Registry) PutArtifact(t testing.TB, repo, tag string, specContent []byte) ocispec.Descriptor {
+	t.Helper()
+
+	createdTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := []byte(`{"created":"2024-01-01T00:00:00Z"}`)
+	m, err := manifest.CreateManifest(specContent, config, manifest.BuildOptions{CreatedTime: &createdTime})
+	if err != nil {
+		t.Fatalf("Failed to create manifest: %v", err)
+	}
+	data, err := m.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+
+	reg.PutBlob(repo, config)
+	reg.PutBlob(repo, specContent)
+	return reg.PutManifest(repo, tag, common.MediaTypeOCIManifest, data)
+}
+
+// PutRuntime stores a performer image and an EigenRuntime artifact whose spec
+// references it, returning the artifact reference.
+func (reg *Registry) PutRuntime(t testing.TB, repo, tag string) string {
+	t.Helper()
+
+	image := reg.PutImage(t, "example/performer", []byte("performer layer"))
+	specContent := []byte(fmt.Sprintf(SpecYAML, reg.Host(), image.Digest))
+	reg.PutArtifact(t, repo, tag, specContent)
+	return fmt.Sprintf("%s/%s:%s", reg.Host(), repo, tag)
+}
+
+// ServeHTTP implements the distribution API, so that tests can wrap the
+// registry in their own handler.
+func (reg *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.requests = append(reg.requests, r.Method+" "+r.URL.Path)
+
+	if len(reg.failures) > 0 {
+		f := reg.failures[0]
+		reg.failures = reg.failures[1:]
+		if f.RetryAfter != "" {
+			w.Header().Set("Retry-After", f.RetryAfter)
+		}
+		writeError(w, f.Status, strings.ToUpper(strings.ReplaceAll(http.StatusText(f.Status), " ", "_")))
+		return
+	}
+
+	if reg.username != "" && !reg.authorize(w, r) {
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if path == "" || path == r.URL.Path {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(path, "/tags/list"):
+		reg.serveTags(w, r, strings.TrimSuffix(path, "/tags/list"))
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		reg.serveManifest(w, r, path[:i], path[i+len("/manifests/"):])
+	case strings.Contains(path, "/blobs/uploads/"):
+		i := strings.LastIndex(path, "/blobs/uploads/")
+		reg.serveUpload(w, r, path[:i])
+	case strings.Contains(path, "/blobs/"):
+		i := strings.LastIndex(path, "/blobs/")
+		reg.serveBlob(w, r, path[:i], digest.Digest(path[i+len("/blobs/"):]))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// authorize serves the token endpoint and challenges requests without a
+// valid token, reporting whether the request may proceed.
+func (reg *Registry) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if r.URL.Path == "/token" {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != reg.username || password != reg.password {
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED")
+			return false
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"token": Token})
+		return false
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test-registry"`, reg.URL))
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED")
+		return false
+	}
+	return true
+}
+
+func (reg *Registry) serveManifest(w http.ResponseWriter, r *http.Request, name, ref string) {
+	repo := reg.repo(name)
+
+	if r.Method == http.MethodPut {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		d := digest.FromBytes(body)
+		repo.manifests[d] = storedManifest{mediaType: r.Header.Get("Content-Type"), content: body}
+		if _, err := digest.Parse(ref); err != nil {
+			repo.tags[ref] = d
+		}
+		w.Header().Set("Docker-Content-Digest", d.String())
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	d, err := digest.Parse(ref)
+	if err != nil {
+		var ok bool
+		if d, ok = repo.tags[ref]; !ok {
+			writeError(w, http.StatusNotFound, "MANIFEST_UNKNOWN")
+			return
+		}
+	}
+	m, ok := repo.manifests[d]
+	if !ok {
+		writeError(w, http.StatusNotFound, "MANIFEST_UNKNOWN")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		if reg.deleteDisabled {
+			writeError(w, http.StatusMethodNotAllowed, "UNSUPPORTED")
+			return
+		}
+		delete(repo.manifests, d)
+		for tag, td := range repo.tags {
+			if td == d {
+				delete(repo.tags, tag)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodGet, http.MethodHead:
+		w.Header().Set("Content-Type", m.mediaType)
+		w.Header().Set("Docker-Content-Digest", d.String())
+		w.Header().Set("Content-Length", strconv.Itoa(len(m.content)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(m.content)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (reg *Registry) serveBlob(w http.ResponseWriter, r *http.Request, name string, d digest.Digest) {
+	content, ok := reg.repo(name).blobs[d]
+	if !ok {
+		writeError(w, http.StatusNotFound, "BLOB_UNKNOWN")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Docker-Content-Digest", d.String())
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (reg *Registry) serveUpload(w http.ResponseWriter, r *http.Request, name string) {
+	switch r.Method {
+	case http.MethodPost:
+		reg.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d", name, reg.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		d := digest.FromBytes(body)
+		if expected := r.URL.Query().Get("digest"); expected != d.String() {
+			writeError(w, http.StatusBadRequest, "DIGEST_INVALID")
+			return
+		}
+		reg.repo(name).blobs[d] = body
+		w.Header().Set("Docker-Content-Digest", d.String())
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (reg *Registry) serveTags(w http.ResponseWriter, r *http.Request, name string) {
+	tags := make([]string, 0, len(reg.repo(name).tags))
+	for tag := range reg.repo(name).tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	if last := r.URL.Query().Get("last"); last != "" {
+		i := sort.SearchStrings(tags, last)
+		if i < len(tags) && tags[i] == last {
+			i++
+		}
+		tags = tags[i:]
+	}
+	if n, err := strconv.Atoi(r.URL.Query().Get("n")); err == nil && n > 0 && n < len(tags) {
+		tags = tags[:n]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=%d&last=%s>; rel="next"`, name, n, tags[n-1]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"name": name,
+		"tags": tags,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"code": code, "message": strings.ToLower(code)}},
+	})
+}
@@ -4,9 +4,20 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 )
 
 func ComputeDigest(content []byte) string {
 	hash := sha256.Sum256(content)
 	return fmt.Sprintf("sha256:%s", hex.EncodeToString(hash[:]))
-}
\ No newline at end of file
+}
+
+// ComputeDigestFromReader computes the digest of everything read from r
+// without buffering the content in memory.
+func ComputeDigestFromReader(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", fmt.Errorf("failed to read content: %w", err)
+	}
+	return fmt.Sprintf("sha256:%s", hex.EncodeToString(hash.Sum(nil))), nil
+}
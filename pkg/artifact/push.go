@@ -8,42 +8,100 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/credentials"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/content/memory"
 	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
 )
 
 type BuildOptions struct {
 	Description string
 	Source      string
-	Version     string
-	Annotations map[string]string
-	CreatedTime *time.Time
+	// Version is the format version of the spec, recorded as the
+	// io.eigenruntime.spec.version annotation. It defaults to
+	// common.DefaultSpecVersion and must be one of
+	// common.SupportedSpecVersions.
+	Version string
+	// RuntimeVersion is the semantic version of the runtime, recorded
+	// normalized as the org.opencontainers.image.version annotation. It
+	// defaults to the version field of the spec and must match it if both
+	// are set.
+	RuntimeVersion string
+	Annotations    map[string]string
+	CreatedTime    *time.Time
 }
 
+// BuildAndPush builds an artifact from specContent and pushes it to
+// reference using default registry settings. Requests that time out or
+// fail with 429 or 5xx are retried with oras' default backoff, and are
+// authenticated with the credentials in the Docker config file (see
+// credentials.Default). Use client.Client.Push to configure retries,
+// transport security or credentials.
 func BuildAndPush(ctx context.Context, specContent []byte, opts BuildOptions, reference string) (string, error) {
+	store, manifestDesc, err := Build(ctx, specContent, opts)
+	if err != nil {
+		return "", err
+	}
+
+	repo, err := remote.NewRepository(reference)
+	if err != nil {
+		return "", fmt.Errorf("failed to create repository: %w", err)
+	}
+	repo.Client = &auth.Client{
+		Client:     retry.DefaultClient,
+		Credential: credentials.Func(credentials.Default()),
+	}
+
+	if err := Push(ctx, store, manifestDesc, repo, reference, oras.DefaultCopyGraphOptions); err != nil {
+		return "", err
+	}
+
+	return string(manifestDesc.Digest), nil
+}
+
+// Build assembles the manifest, config and spec layer of an artifact in an
+// in-memory store and returns the store with the manifest descriptor. Specs
+// with a component still at spec.PlaceholderDigest are refused with a
+// *spec.ValidationError.
+func Build(ctx context.Context, specContent []byte, opts BuildOptions) (*memory.Store, ocispec.Descriptor, error) {
+	runtimeSpec, err := spec.ParseYAML(specContent)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+	if err := spec.CheckPinned(runtimeSpec); err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+
 	// Create minimal config
+	createdTime := time.Now()
+	if opts.CreatedTime != nil {
+		createdTime = *opts.CreatedTime
+	}
 	config := map[string]interface{}{
-		"created": time.Now().Format(time.RFC3339),
+		"created": createdTime.Format(time.RFC3339),
 	}
 	configData, _ := json.Marshal(config)
-	
+
 	// Create manifest
 	manifest, err := createManifest(specContent, configData, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to create manifest: %w", err)
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to create manifest: %w", err)
 	}
-	
+
 	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to marshal manifest: %w", err)
 	}
-	
+
 	// Create memory store and push all components
 	store := memory.New()
-	
+
 	// Store config
 	configDesc := ocispec.Descriptor{
 		MediaType: common.MediaTypeEigenRuntimeConfig,
@@ -51,9 +109,9 @@ func BuildAndPush(ctx context.Context, specContent []byte, opts BuildOptions, re
 		Size:      int64(len(configData)),
 	}
 	if err := store.Push(ctx, configDesc, bytes.NewReader(configData)); err != nil {
-		return "", fmt.Errorf("failed to store config: %w", err)
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to store config: %w", err)
 	}
-	
+
 	// Store spec layer
 	specDesc := ocispec.Descriptor{
 		MediaType: common.MediaTypeYAML,
@@ -61,9 +119,9 @@ func BuildAndPush(ctx context.Context, specContent []byte, opts BuildOptions, re
 		Size:      int64(len(specContent)),
 	}
 	if err := store.Push(ctx, specDesc, bytes.NewReader(specContent)); err != nil {
-		return "", fmt.Errorf("failed to store spec: %w", err)
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to store spec: %w", err)
 	}
-	
+
 	// Store manifest
 	manifestDesc := ocispec.Descriptor{
 		MediaType: common.MediaTypeOCIManifest,
@@ -71,52 +129,66 @@ func BuildAndPush(ctx context.Context, specContent []byte, opts BuildOptions, re
 		Size:      int64(len(manifestJSON)),
 	}
 	if err := store.Push(ctx, manifestDesc, bytes.NewReader(manifestJSON)); err != nil {
-		return "", fmt.Errorf("failed to store manifest: %w", err)
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to store manifest: %w", err)
 	}
-	
-	// Push to registry
-	repo, err := remote.NewRepository(reference)
-	if err != nil {
-		return "", fmt.Errorf("failed to create repository: %w", err)
+
+	return store, manifestDesc, nil
+}
+
+// Push copies the artifact rooted at manifestDesc from store to target and
+// tags it with reference.
+func Push(ctx context.Context, store content.ReadOnlyStorage, manifestDesc ocispec.Descriptor, target oras.Target, reference string, opts oras.CopyGraphOptions) error {
+	if err := oras.CopyGraph(ctx, store, target, manifestDesc, opts); err != nil {
+		return fmt.Errorf("failed to push to registry: %w", err)
 	}
-	
-	_, err = oras.Copy(ctx, store, reference, repo, reference, oras.DefaultCopyOptions)
-	if err != nil {
-		return "", fmt.Errorf("failed to push to registry: %w", err)
+
+	if err := target.Tag(ctx, manifestDesc, reference); err != nil {
+		return fmt.Errorf("failed to tag %s: %w", reference, err)
 	}
-	
-	return string(manifestDesc.Digest), nil
+
+	return nil
 }
 
 func createManifest(specContent []byte, config []byte, opts BuildOptions) (interface{}, error) {
 	if opts.Annotations == nil {
 		opts.Annotations = make(map[string]string)
 	}
-	
+
 	createdTime := time.Now()
 	if opts.CreatedTime != nil {
 		createdTime = *opts.CreatedTime
 	}
-	
-	version := common.DefaultSpecVersion
+
+	specVersion := common.DefaultSpecVersion
 	if opts.Version != "" {
-		version = opts.Version
+		specVersion = opts.Version
+	}
+	if err := spec.ValidateSpecVersion(specVersion); err != nil {
+		return nil, err
+	}
+
+	runtimeVersion, err := spec.ResolveVersion(specContent, opts.RuntimeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Annotations[common.AnnotationSpecVersion] = specVersion
+	if runtimeVersion != "" {
+		opts.Annotations[common.AnnotationImageVersion] = runtimeVersion
 	}
-	
-	opts.Annotations[common.AnnotationSpecVersion] = version
 	opts.Annotations[common.AnnotationImageCreated] = createdTime.Format(time.RFC3339)
-	
+
 	if opts.Description != "" {
 		opts.Annotations[common.AnnotationImageDescription] = opts.Description
 	}
-	
+
 	if opts.Source != "" {
 		opts.Annotations[common.AnnotationImageSource] = opts.Source
 	}
 
 	specDigest := ComputeDigest(specContent)
 	configDigest := ComputeDigest(config)
-	
+
 	manifest := map[string]interface{}{
 		"schemaVersion": 2,
 		"mediaType":     common.MediaTypeOCIManifest,
@@ -135,6 +207,6 @@ func createManifest(specContent []byte, config []byte, opts BuildOptions) (inter
 		},
 		"annotations": opts.Annotations,
 	}
-	
+
 	return manifest, nil
-}
\ No newline at end of file
+}
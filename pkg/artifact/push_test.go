@@ -0,0 +1,47 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+)
+
+const specTemplate = `apiVersion: eigenruntime.io/v1alpha1
+kind: Runtime
+name: test-avs
+version: v0.1.0
+spec:
+  performer:
+    registry: localhost:5000/test/performer
+    digest: %s
+`
+
+func TestBuildPlaceholderDigest(t *testing.T) {
+	specContent := []byte(fmt.Sprintf(specTemplate, spec.PlaceholderDigest))
+
+	_, _, err := Build(context.Background(), specContent, BuildOptions{})
+	var validationErr *spec.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *spec.ValidationError, got %v", err)
+	}
+
+	_, err = BuildAndPush(context.Background(), specContent, BuildOptions{}, "localhost:0/test/runtime:v0.1.0")
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected BuildAndPush to fail with *spec.ValidationError, got %v", err)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	specContent := []byte(fmt.Sprintf(specTemplate, ComputeDigest([]byte("performer"))))
+
+	store, desc, err := Build(context.Background(), specContent, BuildOptions{})
+	if err != nil {
+		t.Fatalf("Failed to build artifact: %v", err)
+	}
+	if exists, err := store.Exists(context.Background(), desc); err != nil || !exists {
+		t.Errorf("Expected the manifest %s in the store, got exists=%v err=%v", desc.Digest, exists, err)
+	}
+}
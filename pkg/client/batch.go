@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/registry"
+)
+
+// DefaultPullConcurrency is the number of references PullMany pulls at once
+// when PullManyOptions.Concurrency is zero.
+const DefaultPullConcurrency = 4
+
+// PullManyOptions controls PullMany.
+type PullManyOptions struct {
+	// Concurrency bounds the number of references resolved and fetched at
+	// once. Zero applies DefaultPullConcurrency.
+	Concurrency int
+}
+
+// PullResult is the outcome of pulling one reference with PullMany.
+type PullResult struct {
+	Reference string
+	// Artifact is shared by every result that resolved to the same digest
+	// and must not be modified.
+	Artifact *common.Artifact
+	Err      error
+}
+
+// PullMany pulls every reference in refs, returning one result per
+// reference in the same order. References are resolved concurrently and
+// each distinct manifest digest is fetched only once, however many
+// references point at it. Cancelling ctx stops in-flight fetches; references
+// not yet pulled fail with the context's error.
+func (c *Client) PullMany(ctx context.Context, refs []string, opts PullManyOptions) []PullResult {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultPullConcurrency
+	}
+
+	results := make([]PullResult, len(refs))
+	pulls := &pullGroup{flights: make(map[string]*pullFlight)}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency && i < len(refs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				art, err := c.pullShared(ctx, pulls, refs[i])
+				results[i] = PullResult{Reference: refs[i], Artifact: art, Err: err}
+			}
+		}()
+	}
+
+	for i, ref := range refs {
+		if ctx.Err() != nil {
+			results[i] = PullResult{Reference: ref, Err: ctx.Err()}
+			continue
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			results[i] = PullResult{Reference: ref, Err: ctx.Err()}
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// pullShared resolves reference and fetches its manifest, sharing the fetch
+// with any other reference in the group that resolved to the same digest.
+func (c *Client) pullShared(ctx context.Context, pulls *pullGroup, reference string) (*common.Artifact, error) {
+	repo, desc, err := c.resolveRepository(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull artifact: %w", registryError(err))
+	}
+
+	return pulls.do(ctx, desc.Digest, func() (*common.Artifact, error) {
+		if c.opts.CacheDir != "" {
+			// The reference is pinned so that the cache does not resolve
+			// the tag a second time.
+			ref, err := registry.ParseReference(reference)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reference: %w", err)
+			}
+			ref.Reference = desc.Digest.String()
+			return c.pullCached(ctx, ref.String())
+		}
+		return c.fetchArtifact(ctx, c.fetchProgress(repo), desc)
+	})
+}
+
+// pullGroup de-duplicates concurrent fetches of the same digest.
+type pullGroup struct {
+	mu      sync.Mutex
+	flights map[string]*pullFlight
+}
+
+type pullFlight struct {
+	done chan struct{}
+	art  *common.Artifact
+	err  error
+}
+
+// do calls fetch for the first caller with digest d and makes every other
+// caller wait for its result. Waiting callers return early if ctx is done.
+func (g *pullGroup) do(ctx context.Context, d digest.Digest, fetch func() (*common.Artifact, error)) (*common.Artifact, error) {
+	g.mu.Lock()
+	flight, ok := g.flights[d.String()]
+	if !ok {
+		flight = &pullFlight{done: make(chan struct{})}
+		g.flights[d.String()] = flight
+	}
+	g.mu.Unlock()
+
+	if !ok {
+		flight.art, flight.err = fetch()
+		close(flight.done)
+		return flight.art, flight.err
+	}
+
+	select {
+	case <-flight.done:
+		return flight.art, flight.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+)
+
+func TestPullMany(t *testing.T) {
+	reg := registrytest.New(t)
+	v1 := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+	stable := reg.PutRuntime(t, "example/runtime", "stable")
+	other := reg.PutRuntime(t, "example/other", "v1.0.0")
+	missing := reg.Host() + "/example/runtime:missing"
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	refs := []string{v1, missing, stable, other, v1}
+
+	results := c.PullMany(context.Background(), refs, PullManyOptions{Concurrency: 2})
+	if len(results) != len(refs) {
+		t.Fatalf("Expected %d results, got %d", len(refs), len(results))
+	}
+
+	for i, result := range results {
+		if result.Reference != refs[i] {
+			t.Errorf("Result %d: expected reference %s, got %s", i, refs[i], result.Reference)
+		}
+		if refs[i] == missing {
+			if !errors.Is(result.Err, ErrNotFound) {
+				t.Errorf("Expected ErrNotFound for %s, got %v", missing, result.Err)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Errorf("Failed to pull %s: %v", refs[i], result.Err)
+		}
+	}
+
+	// Both repositories hold the same content, so every reference resolves
+	// to the same digest.
+	for _, i := range []int{2, 3, 4} {
+		if results[i].Artifact != results[0].Artifact {
+			t.Errorf("Expected %s to share the artifact of %s", refs[i], refs[0])
+		}
+	}
+
+	manifestGets := 0
+	for _, req := range reg.Requests() {
+		if strings.HasPrefix(req, "GET ") && strings.Contains(req, "/manifests/") {
+			manifestGets++
+		}
+	}
+	if manifestGets != 1 {
+		t.Errorf("Expected 1 manifest download, got %d", manifestGets)
+	}
+}
+
+func TestPullManyConcurrent(t *testing.T) {
+	reg := registrytest.New(t)
+
+	var refs []string
+	for i := 0; i < 20; i++ {
+		refs = append(refs, reg.PutRuntime(t, fmt.Sprintf("example/runtime%d", i%5), fmt.Sprintf("v1.0.%d", i)))
+	}
+
+	for _, opts := range []ClientOptions{
+		{PlainHTTP: true},
+		{PlainHTTP: true, CacheDir: t.TempDir()},
+	} {
+		c := NewClient(opts)
+		for _, result := range c.PullMany(context.Background(), refs, PullManyOptions{Concurrency: 8}) {
+			if result.Err != nil {
+				t.Errorf("Failed to pull %s: %v", result.Reference, result.Err)
+			}
+			if result.Artifact == nil || len(result.Artifact.Layers) != 1 {
+				t.Errorf("Expected artifact with one layer for %s", result.Reference)
+			}
+		}
+	}
+}
+
+func TestPullManyCancel(t *testing.T) {
+	reg := registrytest.NewUnstarted(t)
+	// Blob downloads hang until the client gives up.
+	reg.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/blobs/") {
+			<-r.Context().Done()
+			return
+		}
+		reg.ServeHTTP(w, r)
+	})
+	reg.Start()
+
+	var refs []string
+	for i := 0; i < 10; i++ {
+		refs = append(refs, reg.PutRuntime(t, fmt.Sprintf("example/runtime%d", i), "v1.0.0"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	c := NewClient(ClientOptions{PlainHTTP: true, Retry: &RetryPolicy{MaxAttempts: 1}})
+
+	done := make(chan []PullResult)
+	go func() { done <- c.PullMany(ctx, refs, PullManyOptions{Concurrency: 3}) }()
+
+	select {
+	case results := <-done:
+		for _, result := range results {
+			if !errors.Is(result.Err, context.DeadlineExceeded) {
+				t.Errorf("Expected %s to fail with the context error, got %v", result.Reference, result.Err)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("PullMany did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,265 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/manifest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/registry"
+)
+
+// ExportOptions controls what Export writes into a bundle.
+type ExportOptions struct {
+	// IncludeComponents also bundles the container image of every component
+	// declared in the runtime spec, so the bundle is usable fully offline.
+	IncludeComponents bool
+}
+
+// Export writes the artifact identified by reference to w as a tarball in
+// OCI image layout. The artifact is recorded in the layout index under its
+// full reference, and component images under their digest-pinned references.
+func (c *Client) Export(ctx context.Context, reference string, w io.Writer, opts ExportOptions) error {
+	dir, err := os.MkdirTemp("", "eigenruntime-export-")
+	if err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	layout, err := oci.NewWithContext(ctx, dir)
+	if err != nil {
+		return fmt.Errorf("failed to create OCI layout: %w", err)
+	}
+
+	desc, err := c.exportInto(ctx, layout, reference)
+	if err != nil {
+		return fmt.Errorf("failed to export artifact: %w", registryError(err))
+	}
+
+	if opts.IncludeComponents {
+		runtimeSpec, err := c.specFromStore(ctx, layout, desc)
+		if err != nil {
+			return err
+		}
+
+		for name, component := range runtimeSpec.Spec {
+			componentRef := fmt.Sprintf("%s@%s", component.Registry, component.Digest)
+			if _, err := c.exportInto(ctx, layout, componentRef); err != nil {
+				return fmt.Errorf("failed to export component %s: %w", name, registryError(err))
+			}
+		}
+	}
+
+	if err := writeTar(dir, w); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	return nil
+}
+
+// exportInto copies reference, from a mirror if one has it, into layout and
+// records it under its full reference.
+func (c *Client) exportInto(ctx context.Context, layout *oci.Store, reference string) (ocispec.Descriptor, error) {
+	repo, desc, err := c.resolveRepository(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	opts := c.progressCopyGraphOptions(oras.DefaultCopyGraphOptions)
+	if err := oras.CopyGraph(ctx, c.copyProgress(repo), layout, desc, opts); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if err := layout.Tag(ctx, desc, reference); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	return desc, nil
+}
+
+// Import loads a bundle produced by Export into target, which may be a local
+// OCI layout or a remote repository. Every blob is verified against its
+// descriptor while it is copied. Tagged references are re-tagged in target
+// with their tag only; digest-pinned references are copied untagged.
+//
+// Import returns the descriptor of the EigenRuntime manifest in the bundle.
+func (c *Client) Import(ctx context.Context, r io.Reader, target oras.Target) (ocispec.Descriptor, error) {
+	f, err := os.CreateTemp("", "eigenruntime-import-*.tar")
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to create import file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	store, err := oci.NewFromTar(ctx, f.Name())
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to open bundle: %w", err)
+	}
+
+	var names []string
+	if err := store.Tags(ctx, "", func(tags []string) error {
+		names = append(names, tags...)
+		return nil
+	}); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to list bundle contents: %w", err)
+	}
+
+	src := &verifyingTarget{ReadOnlyTarget: c.copyProgress(store)}
+	copyOpts := oras.DefaultCopyOptions
+	copyOpts.CopyGraphOptions = c.progressCopyGraphOptions(copyOpts.CopyGraphOptions)
+
+	var runtimeDesc *ocispec.Descriptor
+	for _, name := range names {
+		desc, err := store.Resolve(ctx, name)
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s in bundle: %w", name, err)
+		}
+
+		ref, err := registry.ParseReference(name)
+		if err != nil {
+			// Only full references are recorded by Export; the bare digest
+			// aliases maintained by the layout itself are skipped.
+			continue
+		}
+
+		if _, err := ref.Digest(); err == nil {
+			err = oras.CopyGraph(ctx, src, target, desc, copyOpts.CopyGraphOptions)
+		} else {
+			desc, err = oras.Copy(ctx, src, name, target, ref.Reference, copyOpts)
+		}
+		if err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to import %s: %w", name, err)
+		}
+
+		if runtimeDesc == nil && c.isRuntimeManifest(ctx, store, desc) {
+			d := desc
+			runtimeDesc = &d
+		}
+	}
+
+	if runtimeDesc == nil {
+		return ocispec.Descriptor{}, fmt.Errorf("no EigenRuntime artifact found in bundle")
+	}
+
+	return *runtimeDesc, nil
+}
+
+func (c *Client) isRuntimeManifest(ctx context.Context, store content.Fetcher, desc ocispec.Descriptor) bool {
+	if desc.MediaType != common.MediaTypeOCIManifest {
+		return false
+	}
+
+	manifestBytes, err := content.FetchAll(ctx, store, desc)
+	if err != nil {
+		return false
+	}
+
+	m, err := manifest.ParseManifest(manifestBytes)
+	if err != nil {
+		return false
+	}
+
+	return m.ArtifactType == common.MediaTypeEigenRuntimeManifest
+}
+
+func (c *Client) specFromStore(ctx context.Context, store content.Fetcher, desc ocispec.Descriptor) (*common.RuntimeSpec, error) {
+	manifestBytes, err := content.FetchAll(ctx, store, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	m, err := manifest.ParseManifest(manifestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m.Layers) == 0 {
+		return nil, fmt.Errorf("no spec layer found in artifact")
+	}
+
+	specBytes, err := content.FetchAll(ctx, store, m.Layers[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch spec layer: %w", err)
+	}
+
+	return spec.ParseYAML(specBytes)
+}
+
+// verifyingTarget wraps a read-only target so that every fetched blob is
+// checked against the size and digest of its descriptor as it is read.
+type verifyingTarget struct {
+	oras.ReadOnlyTarget
+}
+
+func (t *verifyingTarget) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := t.ReadOnlyTarget.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return newVerifyingReadCloser(rc, target), nil
+}
+
+func writeTar(dir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
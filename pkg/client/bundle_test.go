@@ -0,0 +1,119 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"oras.land/oras-go/v2/content/oci"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	ctx := context.Background()
+
+	var bundle bytes.Buffer
+	if err := c.Export(ctx, reference, &bundle, ExportOptions{IncludeComponents: true}); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	layout, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create layout: %v", err)
+	}
+
+	desc, err := c.Import(ctx, bytes.NewReader(bundle.Bytes()), layout)
+	if err != nil {
+		t.Fatalf("Failed to import into layout: %v", err)
+	}
+
+	tagged, err := layout.Resolve(ctx, "v1.0.0")
+	if err != nil {
+		t.Fatalf("Failed to resolve imported tag: %v", err)
+	}
+	if tagged.Digest != desc.Digest {
+		t.Errorf("Expected tag to resolve to %s, got %s", desc.Digest, tagged.Digest)
+	}
+
+	runtimeSpec, err := c.specFromStore(ctx, layout, desc)
+	if err != nil {
+		t.Fatalf("Failed to read imported spec: %v", err)
+	}
+	performer := runtimeSpec.Spec["performer"]
+	if _, err := layout.Resolve(ctx, performer.Digest); err != nil {
+		t.Errorf("Expected component image %s in layout: %v", performer.Digest, err)
+	}
+
+	repo, err := c.createRepository(reg.Host() + "/mirror/runtime")
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	if _, err := c.Import(ctx, bytes.NewReader(bundle.Bytes()), repo); err != nil {
+		t.Fatalf("Failed to import into registry: %v", err)
+	}
+
+	art, err := c.Pull(ctx, reg.Host()+"/mirror/runtime:v1.0.0")
+	if err != nil {
+		t.Fatalf("Failed to pull imported artifact: %v", err)
+	}
+	if art.Digest != string(desc.Digest) {
+		t.Errorf("Expected digest %s, got %s", desc.Digest, art.Digest)
+	}
+}
+
+func TestImportRejectsTamperedBundle(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	ctx := context.Background()
+
+	var bundle bytes.Buffer
+	if err := c.Export(ctx, reference, &bundle, ExportOptions{}); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+
+	// Rewrite the spec layer in place, keeping its size.
+	var tampered bytes.Buffer
+	tr := tar.NewReader(&bundle)
+	tw := tar.NewWriter(&tampered)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read bundle: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("Failed to read bundle entry: %v", err)
+		}
+		if strings.HasPrefix(hdr.Name, "blobs/") {
+			data = bytes.Replace(data, []byte("example-runtime"), []byte("tampered-runtim"), 1)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("Failed to write header: %v", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatalf("Failed to write entry: %v", err)
+		}
+	}
+	tw.Close()
+
+	layout, err := oci.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create layout: %v", err)
+	}
+
+	if _, err := c.Import(ctx, &tampered, layout); err == nil {
+		t.Fatal("Expected import of tampered bundle to fail")
+	}
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/manifest"
+	"github.com/gofrs/flock"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const cacheLockFile = "cache.lock"
+
+// cache is a content-addressable blob store on local disk. Several processes
+// may share one cache directory: pulls hold a shared lock on the lock file
+// while they read and write blobs, and garbage collection holds an exclusive
+// lock so it never removes blobs from under an in-flight pull.
+type cache struct {
+	root    string
+	maxSize int64
+	storage *oci.Storage
+	lock    *flock.Flock
+}
+
+func openCache(dir string, maxSize int64) (*cache, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve cache directory: %w", err)
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	storage, err := oci.NewStorage(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	return &cache{
+		root:    root,
+		maxSize: maxSize,
+		storage: storage,
+		lock:    flock.New(filepath.Join(root, cacheLockFile)),
+	}, nil
+}
+
+func (c *cache) rlock() (func(), error) {
+	if err := c.lock.RLock(); err != nil {
+		return nil, fmt.Errorf("failed to lock cache: %w", err)
+	}
+	return func() { c.lock.Unlock() }, nil
+}
+
+// Fetch returns the cached blob and marks it as recently used.
+func (c *cache) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := c.storage.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	os.Chtimes(c.blobPath(target.Digest), now, now)
+
+	return rc, nil
+}
+
+// Push stores a blob. Blobs written concurrently by another process are not
+// treated as an error, since the content is identical.
+func (c *cache) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	err := c.storage.Push(ctx, expected, content)
+	if errors.Is(err, errdef.ErrAlreadyExists) {
+		return nil
+	}
+	return err
+}
+
+func (c *cache) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	return c.storage.Exists(ctx, target)
+}
+
+// resolve returns the descriptor of a cached manifest, provided the manifest
+// and every blob it references are present.
+func (c *cache) resolve(ctx context.Context, d digest.Digest) (ocispec.Descriptor, bool) {
+	data, err := os.ReadFile(c.blobPath(d))
+	if err != nil || digest.FromBytes(data) != d {
+		return ocispec.Descriptor{}, false
+	}
+
+	var header struct {
+		MediaType string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return ocispec.Descriptor{}, false
+	}
+
+	m, err := manifest.ParseManifest(data)
+	if err != nil {
+		return ocispec.Descriptor{}, false
+	}
+
+	for _, blob := range append([]ocispec.Descriptor{m.Config}, m.Layers...) {
+		if exists, err := c.Exists(ctx, blob); err != nil || !exists {
+			return ocispec.Descriptor{}, false
+		}
+	}
+
+	return ocispec.Descriptor{
+		MediaType: header.MediaType,
+		Digest:    d,
+		Size:      int64(len(data)),
+	}, true
+}
+
+// fill copies the manifest described by desc and all of its blobs from src.
+// The manifest is stored last, so a cached manifest always implies its blobs
+// were complete when it was written. opts is applied to each blob copy.
+func (c *cache) fill(ctx context.Context, src content.ReadOnlyStorage, desc ocispec.Descriptor, limits sizeLimits, opts oras.CopyGraphOptions) error {
+	manifestBytes, m, err := limits.fetchManifest(ctx, src, desc)
+	if err != nil {
+		return err
+	}
+
+	for _, blob := range append([]ocispec.Descriptor{m.Config}, m.Layers...) {
+		if err := oras.CopyGraph(ctx, src, c, blob, opts); err != nil {
+			return err
+		}
+	}
+
+	return c.Push(ctx, desc, bytes.NewReader(manifestBytes))
+}
+
+// gc removes the least recently used blobs until the cache fits within
+// maxSize. It is skipped if another process currently holds the cache.
+func (c *cache) gc() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+
+	locked, err := c.lock.TryLock()
+	if err != nil {
+		return fmt.Errorf("failed to lock cache: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer c.lock.Unlock()
+
+	type blob struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var blobs []blob
+	var total int64
+	err = filepath.WalkDir(filepath.Join(c.root, ocispec.ImageBlobsDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		blobs = append(blobs, blob{path: path, size: info.Size(), modTime: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan cache: %w", err)
+	}
+
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].modTime.Before(blobs[j].modTime)
+	})
+
+	for _, b := range blobs {
+		if total <= c.maxSize {
+			break
+		}
+		// Blobs are stored read-only; make them writable first for platforms
+		// that refuse to remove read-only files.
+		os.Chmod(b.path, 0644)
+		if err := os.Remove(b.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove cached blob: %w", err)
+		}
+		total -= b.size
+	}
+
+	return nil
+}
+
+func (c *cache) blobPath(d digest.Digest) string {
+	return filepath.Join(c.root, ocispec.ImageBlobsDir, d.Algorithm().String(), d.Encoded())
+}
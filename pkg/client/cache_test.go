@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+)
+
+func TestPullCachedServesDigestOffline(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true, CacheDir: t.TempDir()})
+	ctx := context.Background()
+
+	first, err := c.Pull(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+
+	reg.Close()
+
+	pinned := reg.Host() + "/example/runtime@" + first.Digest
+	second, err := c.Pull(ctx, pinned)
+	if err != nil {
+		t.Fatalf("Failed to pull from cache while offline: %v", err)
+	}
+	if string(second.Layers[0].Content) != string(first.Layers[0].Content) {
+		t.Error("Cached spec layer differs from pulled spec layer")
+	}
+}
+
+func TestPullCachedRevalidatesTag(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true, CacheDir: t.TempDir()})
+	ctx := context.Background()
+
+	if _, err := c.Pull(ctx, reference); err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+	before := len(reg.Requests())
+
+	if _, err := c.Pull(ctx, reference); err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+
+	for _, req := range reg.Requests()[before:] {
+		if !strings.HasPrefix(req, "HEAD ") && req != "GET /v2/" {
+			t.Errorf("Expected only a HEAD request for a cached tag, got %s", req)
+		}
+	}
+}
+
+func TestPruneCache(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	if _, err := NewClient(ClientOptions{PlainHTTP: true, CacheDir: dir}).Pull(ctx, reference); err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+
+	if size := cacheSize(t, dir); size == 0 {
+		t.Fatal("Expected cache to contain blobs")
+	}
+
+	if err := NewClient(ClientOptions{CacheDir: dir, CacheMaxSize: 1}).PruneCache(); err != nil {
+		t.Fatalf("Failed to prune cache: %v", err)
+	}
+
+	if size := cacheSize(t, dir); size > 1 {
+		t.Errorf("Expected cache to be pruned to at most 1 byte, got %d", size)
+	}
+
+	// A pruned cache is transparently refilled from the registry.
+	if _, err := NewClient(ClientOptions{PlainHTTP: true, CacheDir: dir}).Pull(ctx, reference); err != nil {
+		t.Fatalf("Failed to pull after prune: %v", err)
+	}
+}
+
+func cacheSize(t *testing.T, dir string) int64 {
+	t.Helper()
+
+	var total int64
+	err := filepath.Walk(filepath.Join(dir, "blobs"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			total += info.Size()
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("Failed to walk cache: %v", err)
+	}
+	return total
+}
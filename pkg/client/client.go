@@ -3,24 +3,99 @@ package client
 import (
 	"context"
 	"fmt"
-	"io"
+	"log/slog"
+	"net/http"
+	"sync"
 
 	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
-	"github.com/Layr-Labs/eigenruntime-go/pkg/manifest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/credentials"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"oras.land/oras-go/v2"
-	"oras.land/oras-go/v2/content/memory"
-	"oras.land/oras-go/v2/registry/remote"
-	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
 )
 
 type ClientOptions struct {
 	PlainHTTP bool
+
+	// CacheDir enables an on-disk content cache shared by every client and
+	// process pointing at the same directory. Digest-pinned references are
+	// served from the cache without contacting the registry; tag references
+	// are re-resolved with a HEAD request before the cache is consulted.
+	CacheDir string
+	// CacheMaxSize bounds the size of the cache in bytes. Least recently used
+	// blobs are removed after each pull once it is exceeded. Zero disables
+	// garbage collection.
+	CacheMaxSize int64
+
+	// TagListPageSize is the number of tags requested per page when listing
+	// tags. Zero lets the registry choose.
+	TagListPageSize int
+
+	// MaxBlobSize limits the size of any single manifest, config or layer
+	// read while pulling. Zero applies DefaultMaxBlobSize; a negative value
+	// disables the limit.
+	MaxBlobSize int64
+	// MaxTotalSize limits the combined size of all blobs of one artifact.
+	// Zero applies DefaultMaxTotalSize; a negative value disables the limit.
+	MaxTotalSize int64
+
+	// Retry controls retries of registry requests. Nil applies
+	// DefaultRetryPolicy.
+	Retry *RetryPolicy
+
+	// TLS configures transport security for every registry that does not
+	// override it in Registries.
+	TLS *TLSConfig
+	// ProxyURL routes registry requests through an HTTP proxy. When empty,
+	// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+	// NoProxy lists hosts that bypass ProxyURL. Entries starting with a dot
+	// match all subdomains.
+	NoProxy []string
+	// Registries holds per-registry settings keyed by host[:port], such as
+	// "ghcr.io" or "localhost:5000".
+	Registries map[string]RegistryConfig
+
+	// Credentials supplies registry credentials to every pull, push and
+	// other registry operation, and receives the credentials saved by Login.
+	// Nil makes anonymous requests.
+	Credentials credentials.Store
+
+	// Logger receives structured logs of registry requests and of each
+	// resolve, fetch and push step. Nil disables logging.
+	Logger *slog.Logger
+	// TracerProvider creates OpenTelemetry spans around resolve, fetch and
+	// push steps. Nil disables tracing.
+	TracerProvider trace.TracerProvider
+	// Metrics receives per-host request durations, errors and byte counts.
+	// Nil disables metrics.
+	Metrics Metrics
+
+	// Progress receives an event as each blob starts, advances, is skipped
+	// because the destination already has it, and completes, during pulls,
+	// pushes, copies, exports and imports. Nil disables progress reporting.
+	Progress ProgressFunc
+
+	// UpgradePolicy, when set, is enforced by Push against the highest
+	// semantic version with the same major version already published in
+	// the target repository, and by CheckUpgrade in place of
+	// spec.DefaultUpgradePolicy.
+	UpgradePolicy *spec.UpgradePolicy
+	// AdmissionPolicy, when set, must be satisfied by every spec before
+	// Push publishes it.
+	AdmissionPolicy *spec.AdmissionPolicy
 }
 
 type Client struct {
 	opts ClientOptions
+
+	mu         sync.Mutex
+	transports map[string]http.RoundTripper
 }
 
 func NewClient(opts ClientOptions) *Client {
@@ -29,19 +104,20 @@ func NewClient(opts ClientOptions) *Client {
 	}
 }
 
-func (c *Client) Pull(ctx context.Context, reference string) (*common.Artifact, error) {
-	repo, err := c.createRepository(reference)
-	if err != nil {
-		return nil, err
+func (c *Client) Pull(ctx context.Context, reference string) (art *common.Artifact, err error) {
+	ctx, span := c.startSpan(ctx, "eigenruntime.Pull", attribute.String("oci.reference", reference))
+	defer func() { endSpan(span, err) }()
+
+	if c.opts.CacheDir != "" {
+		return c.pullCached(ctx, reference)
 	}
 
-	store := memory.New()
-	manifestDesc, err := oras.Copy(ctx, repo, reference, store, reference, oras.DefaultCopyOptions)
+	repo, manifestDesc, err := c.resolveRepository(ctx, reference)
 	if err != nil {
-		return nil, fmt.Errorf("failed to pull artifact: %w", err)
+		return nil, fmt.Errorf("failed to pull artifact: %w", registryError(err))
 	}
 
-	return c.fetchArtifact(ctx, store, manifestDesc)
+	return c.fetchArtifact(ctx, c.fetchProgress(repo), manifestDesc)
 }
 
 func (c *Client) PullByDigest(ctx context.Context, registry, digestStr string) (*common.Artifact, error) {
@@ -67,59 +143,108 @@ func (c *Client) FetchSpec(ctx context.Context, reference string) ([]byte, error
 	return art.Layers[0].Content, nil
 }
 
-func (c *Client) createRepository(reference string) (oras.Target, error) {
-	repo, err := remote.NewRepository(reference)
+func (c *Client) fetchRuntimeSpec(ctx context.Context, reference string) (*common.RuntimeSpec, error) {
+	specContent, err := c.FetchSpec(ctx, reference)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create repository: %w", err)
+		return nil, err
+	}
+
+	return spec.ParseYAML(specContent)
+}
+
+// PruneCache removes least recently used blobs from the cache until it fits
+// within CacheMaxSize.
+func (c *Client) PruneCache() error {
+	if c.opts.CacheDir == "" {
+		return nil
 	}
 
-	repo.Client = &auth.Client{
-		Credential: auth.StaticCredential("", auth.EmptyCredential),
+	cache, err := openCache(c.opts.CacheDir, c.opts.CacheMaxSize)
+	if err != nil {
+		return err
 	}
-	repo.PlainHTTP = c.opts.PlainHTTP
 
-	return repo, nil
+	return cache.gc()
 }
 
-func (c *Client) fetchArtifact(ctx context.Context, store *memory.Store, desc ocispec.Descriptor) (*common.Artifact, error) {
-	manifestRC, err := store.Fetch(ctx, desc)
+func (c *Client) pullCached(ctx context.Context, reference string) (*common.Artifact, error) {
+	cache, err := openCache(c.opts.CacheDir, c.opts.CacheMaxSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch manifest from store: %w", err)
+		return nil, err
 	}
-	defer manifestRC.Close()
 
-	manifestBytes, err := io.ReadAll(manifestRC)
+	art, err := c.pullThroughCache(ctx, cache, reference)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read manifest: %w", err)
+		return nil, err
+	}
+
+	if err := cache.gc(); err != nil {
+		return nil, err
 	}
 
-	m, err := manifest.ParseManifest(manifestBytes)
+	return art, nil
+}
+
+func (c *Client) pullThroughCache(ctx context.Context, cache *cache, reference string) (*common.Artifact, error) {
+	unlock, err := cache.rlock()
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
 
-	configRC, err := store.Fetch(ctx, m.Config)
+	ref, err := registry.ParseReference(reference)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch config: %w", err)
+		return nil, fmt.Errorf("invalid reference: %w", err)
+	}
+
+	if d, err := ref.Digest(); err == nil {
+		if desc, ok := cache.resolve(ctx, d); ok {
+			return c.fetchArtifact(ctx, cache, desc)
+		}
 	}
-	defer configRC.Close()
 
-	configBytes, err := io.ReadAll(configRC)
+	repo, desc, err := c.resolveRepository(ctx, reference)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config: %w", err)
+		return nil, fmt.Errorf("failed to resolve artifact: %w", registryError(err))
 	}
 
-	var layers []common.Layer
-	for _, layerDesc := range m.Layers {
-		layerRC, err := store.Fetch(ctx, layerDesc)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch layer: %w", err)
+	if _, ok := cache.resolve(ctx, desc.Digest); !ok {
+		opts := oras.DefaultCopyGraphOptions
+		if c.opts.Progress != nil {
+			opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+				c.reportProgress(ProgressSkipped, desc, 0)
+				return nil
+			}
+		}
+
+		src := c.fetchProgressStorage(c.tracedStorage(repo))
+		if err := cache.fill(ctx, src, desc, c.sizeLimits(), opts); err != nil {
+			return nil, fmt.Errorf("failed to pull artifact: %w", registryError(err))
 		}
+	}
+
+	return c.fetchArtifact(ctx, cache, desc)
+}
+
+func (c *Client) fetchArtifact(ctx context.Context, store content.Fetcher, desc ocispec.Descriptor) (*common.Artifact, error) {
+	limits := c.sizeLimits()
+	store = c.traced(store)
+
+	manifestBytes, m, err := limits.fetchManifest(ctx, store, desc)
+	if err != nil {
+		return nil, err
+	}
 
-		layerBytes, err := io.ReadAll(layerRC)
-		layerRC.Close()
+	configBytes, err := limits.fetch(ctx, store, m.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config: %w", registryError(err))
+	}
+
+	var layers []common.Layer
+	for _, layerDesc := range m.Layers {
+		layerBytes, err := limits.fetch(ctx, store, layerDesc)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read layer: %w", err)
+			return nil, fmt.Errorf("failed to fetch layer: %w", registryError(err))
 		}
 
 		layers = append(layers, common.Layer{
@@ -138,4 +263,4 @@ func (c *Client) fetchArtifact(ctx context.Context, store *memory.Store, desc oc
 		MediaType:    desc.MediaType,
 		ArtifactType: m.ArtifactType,
 	}, nil
-}
\ No newline at end of file
+}
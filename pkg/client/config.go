@@ -0,0 +1,80 @@
+package client
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// registryConfigFile is the file read by LoadRegistryConfig:
+//
+//	proxy: http://proxy.internal:3128
+//	noProxy: [.internal]
+//	registries:
+//	  localhost:5000:
+//	    plainHTTP: true
+//	  ghcr.io:
+//	    mirrors:
+//	      - location: mirror.internal:5000/ghcr
+type registryConfigFile struct {
+	TLS        *fileTLSConfig                `yaml:"tls"`
+	Proxy      string                        `yaml:"proxy"`
+	NoProxy    []string                      `yaml:"noProxy"`
+	Registries map[string]fileRegistryConfig `yaml:"registries"`
+}
+
+type fileTLSConfig struct {
+	CAFile             string `yaml:"caFile"`
+	CertFile           string `yaml:"certFile"`
+	KeyFile            string `yaml:"keyFile"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+type fileRegistryConfig struct {
+	PlainHTTP bool           `yaml:"plainHTTP"`
+	TLS       *fileTLSConfig `yaml:"tls"`
+	Mirrors   []struct {
+		Location  string `yaml:"location"`
+		PlainHTTP bool   `yaml:"plainHTTP"`
+	} `yaml:"mirrors"`
+}
+
+func (t *fileTLSConfig) options() *TLSConfig {
+	if t == nil {
+		return nil
+	}
+	return &TLSConfig{
+		CAFile:             t.CAFile,
+		CertFile:           t.CertFile,
+		KeyFile:            t.KeyFile,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+}
+
+// LoadRegistryConfig applies the registry config file at path to opts,
+// setting its TLS, proxy and per-registry settings.
+func LoadRegistryConfig(path string, opts *ClientOptions) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read registry config: %w", err)
+	}
+
+	var file registryConfigFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse registry config %s: %w", path, err)
+	}
+
+	opts.TLS = file.TLS.options()
+	opts.ProxyURL = file.Proxy
+	opts.NoProxy = file.NoProxy
+	opts.Registries = make(map[string]RegistryConfig, len(file.Registries))
+	for host, reg := range file.Registries {
+		cfg := RegistryConfig{PlainHTTP: reg.PlainHTTP, TLS: reg.TLS.options()}
+		for _, m := range reg.Mirrors {
+			cfg.Mirrors = append(cfg.Mirrors, Mirror{Location: m.Location, PlainHTTP: m.PlainHTTP})
+		}
+		opts.Registries[host] = cfg
+	}
+	return nil
+}
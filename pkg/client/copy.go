@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// Copy copies the artifact referenced by srcRef, with all of its blobs, to
+// dstRef, which may be in another repository or on another registry. Blobs
+// the destination already has are skipped. A tagged dstRef is tagged once
+// the copy completes; a digest-pinned dstRef must match the source.
+//
+// Copy returns the descriptor of the copied manifest.
+func (c *Client) Copy(ctx context.Context, srcRef, dstRef string) (_ ocispec.Descriptor, err error) {
+	ctx, span := c.startSpan(ctx, "eigenruntime.Copy",
+		attribute.String("oci.reference", srcRef), attribute.String("oci.destination", dstRef))
+	defer func() { endSpan(span, err) }()
+
+	dst, err := registry.ParseReference(dstRef)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("invalid reference: %w", err)
+	}
+
+	src, desc, err := c.resolveRepository(ctx, srcRef)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", srcRef, registryError(err))
+	}
+
+	if d, err := dst.Digest(); err == nil && d != desc.Digest {
+		return ocispec.Descriptor{}, fmt.Errorf("destination digest %s does not match source digest %s", d, desc.Digest)
+	}
+
+	target, err := c.createRepository(dstRef)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	opts := c.progressCopyGraphOptions(oras.DefaultCopyGraphOptions)
+	if err := oras.CopyGraph(ctx, c.copyProgress(src), target, desc, opts); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to copy %s to %s: %w", srcRef, dstRef, registryError(err))
+	}
+
+	if _, err := dst.Digest(); err != nil {
+		if err := target.Tag(ctx, desc, dst.Reference); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("failed to tag %s: %w", dstRef, registryError(err))
+		}
+	}
+
+	c.logger().InfoContext(ctx, "copied artifact", "source", srcRef, "destination", dstRef, "digest", desc.Digest)
+
+	return desc, nil
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// Registry errors returned by the client are classified by wrapping one of
+// these sentinels, so callers can branch with errors.Is while still reaching
+// the underlying *errcode.ErrorResponse with errors.As.
+var (
+	// ErrUnauthorized indicates the registry rejected the credentials, or
+	// that credentials are required.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound indicates the repository, manifest or blob does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrTransient indicates a failure that may succeed if retried later,
+	// such as rate limiting, a server error or a network timeout. It is
+	// returned once the retry policy has been exhausted.
+	ErrTransient = errors.New("transient registry error")
+)
+
+// registryError wraps err with the sentinel matching its cause. Errors that
+// do not come from the registry are returned unchanged.
+func registryError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if kind := classifyError(err); kind != nil && !errors.Is(err, kind) {
+		return fmt.Errorf("%w: %w", kind, err)
+	}
+
+	return err
+}
+
+func classifyError(err error) error {
+	var errResp *errcode.ErrorResponse
+	if errors.As(err, &errResp) {
+		switch {
+		case errResp.StatusCode == http.StatusUnauthorized || errResp.StatusCode == http.StatusForbidden:
+			return ErrUnauthorized
+		case errResp.StatusCode == http.StatusNotFound:
+			return ErrNotFound
+		case errResp.StatusCode == http.StatusRequestTimeout ||
+			errResp.StatusCode == http.StatusTooManyRequests ||
+			errResp.StatusCode >= http.StatusInternalServerError:
+			return ErrTransient
+		}
+		return nil
+	}
+
+	if errors.Is(err, errdef.ErrNotFound) {
+		return ErrNotFound
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrTransient
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/manifest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// DefaultMaxBlobSize is the per-blob limit applied when
+	// ClientOptions.MaxBlobSize is zero.
+	DefaultMaxBlobSize = 32 << 20
+	// DefaultMaxTotalSize is the per-artifact limit applied when
+	// ClientOptions.MaxTotalSize is zero.
+	DefaultMaxTotalSize = 128 << 20
+)
+
+// sizeLimits bounds how much content a single pull may read. A negative
+// limit disables the corresponding check.
+type sizeLimits struct {
+	blob  int64
+	total int64
+}
+
+func (c *Client) sizeLimits() sizeLimits {
+	limits := sizeLimits{
+		blob:  c.opts.MaxBlobSize,
+		total: c.opts.MaxTotalSize,
+	}
+	if limits.blob == 0 {
+		limits.blob = DefaultMaxBlobSize
+	}
+	if limits.total == 0 {
+		limits.total = DefaultMaxTotalSize
+	}
+	return limits
+}
+
+// check rejects descriptors that would exceed the limits before any of their
+// content is requested.
+func (l sizeLimits) check(descs ...ocispec.Descriptor) error {
+	var total int64
+	for _, desc := range descs {
+		if desc.Size < 0 {
+			return fmt.Errorf("%s: %w", desc.Digest, content.ErrInvalidDescriptorSize)
+		}
+		if l.blob >= 0 && desc.Size > l.blob {
+			return fmt.Errorf("blob %s is %d bytes, limit is %d: %w", desc.Digest, desc.Size, l.blob, errdef.ErrSizeExceedsLimit)
+		}
+		total += desc.Size
+	}
+
+	if l.total >= 0 && total > l.total {
+		return fmt.Errorf("artifact is %d bytes, limit is %d: %w", total, l.total, errdef.ErrSizeExceedsLimit)
+	}
+
+	return nil
+}
+
+// fetch reads the blob described by desc, verifying its size and digest.
+// At most desc.Size bytes are read regardless of what the source sends.
+func (l sizeLimits) fetch(ctx context.Context, store content.Fetcher, desc ocispec.Descriptor) ([]byte, error) {
+	if err := l.check(desc); err != nil {
+		return nil, err
+	}
+
+	rc, err := store.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return content.ReadAll(rc, desc)
+}
+
+// fetchManifest reads and parses the manifest described by desc and checks
+// the sizes of everything it references against the limits.
+func (l sizeLimits) fetchManifest(ctx context.Context, store content.Fetcher, desc ocispec.Descriptor) ([]byte, *manifest.Manifest, error) {
+	manifestBytes, err := l.fetch(ctx, store, desc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch manifest: %w", registryError(err))
+	}
+
+	m, err := manifest.ParseManifest(manifestBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	descs := append([]ocispec.Descriptor{desc, m.Config}, m.Layers...)
+	if err := l.check(descs...); err != nil {
+		return nil, nil, err
+	}
+
+	return manifestBytes, m, nil
+}
+
+// verifyingReadCloser verifies the size and digest of a blob as it is
+// streamed, reporting a mismatch instead of io.EOF at the end.
+type verifyingReadCloser struct {
+	*content.VerifyReader
+	io.Closer
+	digest string
+}
+
+func newVerifyingReadCloser(rc io.ReadCloser, desc ocispec.Descriptor) io.ReadCloser {
+	return &verifyingReadCloser{
+		VerifyReader: content.NewVerifyReader(rc, desc),
+		Closer:       rc,
+		digest:       desc.Digest.String(),
+	}
+}
+
+func (v *verifyingReadCloser) Read(p []byte) (int, error) {
+	n, err := v.VerifyReader.Read(p)
+	if err == io.EOF {
+		if verr := v.VerifyReader.Verify(); verr != nil {
+			return n, fmt.Errorf("failed to verify %s: %w", v.digest, verr)
+		}
+	}
+	return n, err
+}
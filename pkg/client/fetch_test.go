@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/opencontainers/go-digest"
+	"oras.land/oras-go/v2/errdef"
+)
+
+func TestPullSizeLimits(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts ClientOptions
+	}{
+		{name: "blob limit", opts: ClientOptions{PlainHTTP: true, MaxBlobSize: 64}},
+		{name: "total limit", opts: ClientOptions{PlainHTTP: true, MaxTotalSize: 256}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(reg.Requests())
+
+			_, err := NewClient(tt.opts).Pull(ctx, reference)
+			if !errors.Is(err, errdef.ErrSizeExceedsLimit) {
+				t.Fatalf("Expected ErrSizeExceedsLimit, got %v", err)
+			}
+
+			for _, req := range reg.Requests()[before:] {
+				if strings.Contains(req, "/blobs/") {
+					t.Errorf("Expected no blobs to be fetched, got %s", req)
+				}
+			}
+		})
+	}
+
+	if _, err := NewClient(ClientOptions{PlainHTTP: true, MaxBlobSize: -1, MaxTotalSize: -1}).Pull(ctx, reference); err != nil {
+		t.Errorf("Expected pull without limits to succeed: %v", err)
+	}
+}
+
+func TestPullRejectsOversizedBlob(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+	ctx := context.Background()
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	art, err := c.Pull(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+
+	spec := art.Layers[0]
+	reg.CorruptBlob("example/runtime", digest.Digest(spec.Digest), append(spec.Content, strings.Repeat("x", 1<<20)...))
+
+	if _, err := c.Pull(ctx, reference); err == nil {
+		t.Fatal("Expected pull of oversized blob to fail")
+	}
+}
+
+func TestPullLazy(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+	ctx := context.Background()
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	art, err := c.PullLazy(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to pull lazily: %v", err)
+	}
+
+	for _, req := range reg.Requests() {
+		if strings.Contains(req, art.Layers[0].Digest) {
+			t.Errorf("Expected spec layer not to be fetched before Open, got %s", req)
+		}
+	}
+
+	rc, err := art.Layers[0].Open(ctx)
+	if err != nil {
+		t.Fatalf("Failed to open layer: %v", err)
+	}
+	content, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("Failed to read layer: %v", err)
+	}
+	if !strings.Contains(string(content), "example-runtime") {
+		t.Errorf("Unexpected spec content: %s", content)
+	}
+
+	tampered := strings.Replace(string(content), "example-runtime", "tampered-runtim", 1)
+	reg.CorruptBlob("example/runtime", digest.Digest(art.Layers[0].Digest), []byte(tampered))
+
+	rc, err = art.Layers[0].Open(ctx)
+	if err != nil {
+		t.Fatalf("Failed to open layer: %v", err)
+	}
+	defer rc.Close()
+	if _, err := io.ReadAll(rc); err == nil {
+		t.Error("Expected reading a tampered layer to fail verification")
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ArtifactInfo describes an artifact as returned by Inspect.
+type ArtifactInfo struct {
+	Reference    string `json:"reference"`
+	Digest       string `json:"digest"`
+	MediaType    string `json:"mediaType"`
+	ArtifactType string `json:"artifactType,omitempty"`
+	// Size is the size of the manifest; Layers lists the sizes of the
+	// blobs it references.
+	Size int64 `json:"size"`
+
+	// SpecVersion, Version, Created and Source are read from the
+	// io.eigenruntime.spec.version, org.opencontainers.image.version,
+	// org.opencontainers.image.created and org.opencontainers.image.source
+	// annotations, which also appear in Annotations.
+	SpecVersion string            `json:"specVersion,omitempty"`
+	Version     string            `json:"version,omitempty"`
+	Created     string            `json:"created,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	Config     json.RawMessage `json:"config,omitempty"`
+	Layers     []LayerInfo     `json:"layers"`
+	Name       string          `json:"name"`
+	Components []ComponentInfo `json:"components"`
+}
+
+// LayerInfo describes a layer of an inspected artifact.
+type LayerInfo struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// ComponentInfo summarizes a component of an inspected artifact's spec.
+type ComponentInfo struct {
+	Name        string   `json:"name"`
+	Registry    string   `json:"registry"`
+	Digest      string   `json:"digest"`
+	TEEEnabled  bool     `json:"teeEnabled"`
+	RequiredEnv []string `json:"requiredEnv,omitempty"`
+}
+
+// Inspect describes the artifact at reference. It reads the manifest, the
+// config and the spec layer only; component images referenced by the spec
+// are not downloaded. Inspect always reads from the registry.
+func (c *Client) Inspect(ctx context.Context, reference string) (_ *ArtifactInfo, err error) {
+	ctx, span := c.startSpan(ctx, "eigenruntime.Inspect", attribute.String("oci.reference", reference))
+	defer func() { endSpan(span, err) }()
+
+	repo, desc, err := c.resolveRepository(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect artifact: %w", registryError(err))
+	}
+	span.SetAttributes(descriptorAttributes(desc)...)
+
+	limits := c.sizeLimits()
+	store := c.traced(repo)
+
+	_, m, err := limits.fetchManifest(ctx, store, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := limits.fetch(ctx, store, m.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config: %w", registryError(err))
+	}
+
+	info := &ArtifactInfo{
+		Reference:    reference,
+		Digest:       string(desc.Digest),
+		MediaType:    desc.MediaType,
+		ArtifactType: m.ArtifactType,
+		Size:         desc.Size,
+		SpecVersion:  m.Annotations[common.AnnotationSpecVersion],
+		Version:      m.Annotations[common.AnnotationImageVersion],
+		Created:      m.Annotations[common.AnnotationImageCreated],
+		Source:       m.Annotations[common.AnnotationImageSource],
+		Annotations:  m.Annotations,
+		Layers:       make([]LayerInfo, 0, len(m.Layers)),
+		Components:   []ComponentInfo{},
+	}
+	if json.Valid(config) {
+		info.Config = config
+	}
+
+	var specDesc *ocispec.Descriptor
+	for i, l := range m.Layers {
+		info.Layers = append(info.Layers, LayerInfo{MediaType: l.MediaType, Digest: string(l.Digest), Size: l.Size})
+		if specDesc == nil && l.MediaType == common.MediaTypeYAML {
+			specDesc = &m.Layers[i]
+		}
+	}
+	if specDesc == nil {
+		return nil, fmt.Errorf("no spec layer found in artifact")
+	}
+
+	specContent, err := limits.fetch(ctx, store, *specDesc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch spec: %w", registryError(err))
+	}
+	runtimeSpec, err := spec.ParseYAML(specContent)
+	if err != nil {
+		return nil, err
+	}
+
+	info.Name = runtimeSpec.Name
+	info.Components = summarizeComponents(runtimeSpec)
+	return info, nil
+}
+
+func summarizeComponents(runtimeSpec *common.RuntimeSpec) []ComponentInfo {
+	components := make([]ComponentInfo, 0, len(runtimeSpec.Spec))
+	for name, comp := range runtimeSpec.Spec {
+		info := ComponentInfo{
+			Name:       name,
+			Registry:   comp.Registry,
+			Digest:     comp.Digest,
+			TEEEnabled: comp.Resources != nil && comp.Resources.TEEEnabled,
+		}
+		for _, env := range comp.Env {
+			if env.Required {
+				info.RequiredEnv = append(info.RequiredEnv, env.Name)
+			}
+		}
+		components = append(components, info)
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i].Name < components[j].Name })
+	return components
+}
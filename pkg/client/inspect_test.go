@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+func TestInspect(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	info, err := c.Inspect(context.Background(), reference)
+	if err != nil {
+		t.Fatalf("Failed to inspect artifact: %v", err)
+	}
+
+	if info.Reference != reference {
+		t.Errorf("Expected reference %s, got %s", reference, info.Reference)
+	}
+	if !strings.HasPrefix(info.Digest, "sha256:") || info.Size == 0 {
+		t.Errorf("Expected manifest digest and size, got %s (%d bytes)", info.Digest, info.Size)
+	}
+	if info.MediaType != common.MediaTypeOCIManifest {
+		t.Errorf("Expected media type %s, got %s", common.MediaTypeOCIManifest, info.MediaType)
+	}
+	if info.Name != "example-runtime" {
+		t.Errorf("Expected name example-runtime, got %s", info.Name)
+	}
+	if info.Created != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected created annotation, got %q", info.Created)
+	}
+
+	var config map[string]any
+	if err := json.Unmarshal(info.Config, &config); err != nil {
+		t.Fatalf("Failed to decode config: %v", err)
+	}
+	if config["created"] != "2024-01-01T00:00:00Z" {
+		t.Errorf("Unexpected config: %s", info.Config)
+	}
+
+	if len(info.Layers) != 1 || info.Layers[0].MediaType != common.MediaTypeYAML {
+		t.Fatalf("Expected a single spec layer, got %+v", info.Layers)
+	}
+
+	if len(info.Components) != 1 {
+		t.Fatalf("Expected 1 component, got %d", len(info.Components))
+	}
+	comp := info.Components[0]
+	if comp.Name != "performer" || comp.Registry != reg.Host()+"/example/performer" || comp.TEEEnabled {
+		t.Errorf("Unexpected component: %+v", comp)
+	}
+	if len(comp.RequiredEnv) != 1 || comp.RequiredEnv[0] != "env_var" {
+		t.Errorf("Expected required env [env_var], got %v", comp.RequiredEnv)
+	}
+
+	for _, req := range reg.Requests() {
+		if strings.Contains(req, "/example/performer/") {
+			t.Errorf("Expected component images not to be fetched, got %s", req)
+		}
+	}
+}
+
+func TestInspectNotFound(t *testing.T) {
+	reg := registrytest.New(t)
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	if _, err := c.Inspect(context.Background(), reg.Host()+"/example/missing:v1"); err == nil {
+		t.Error("Expected inspecting a missing artifact to fail")
+	}
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// LazyArtifact is an artifact whose manifest and config have been fetched
+// but whose layers are only downloaded when opened.
+type LazyArtifact struct {
+	Manifest     []byte
+	Config       []byte
+	Layers       []LazyLayer
+	Digest       string
+	MediaType    string
+	ArtifactType string
+}
+
+// LazyLayer describes a layer of a LazyArtifact.
+type LazyLayer struct {
+	MediaType string
+	Digest    string
+	Size      int64
+
+	desc  ocispec.Descriptor
+	store content.Fetcher
+}
+
+// Open starts downloading the layer. The returned reader verifies the size
+// and digest of the content as it is read and fails at the end of the
+// stream if either does not match.
+func (l *LazyLayer) Open(ctx context.Context) (io.ReadCloser, error) {
+	rc, err := l.store.Fetch(ctx, l.desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch layer: %w", registryError(err))
+	}
+
+	return newVerifyingReadCloser(rc, l.desc), nil
+}
+
+// PullLazy resolves reference and fetches its manifest and config, deferring
+// layer downloads to LazyLayer.Open. Size limits are checked against the
+// manifest before returning. PullLazy always reads from the registry and
+// does not populate the local cache.
+func (c *Client) PullLazy(ctx context.Context, reference string) (*LazyArtifact, error) {
+	repo, desc, err := c.resolveRepository(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull artifact: %w", registryError(err))
+	}
+
+	limits := c.sizeLimits()
+	store := c.traced(c.fetchProgress(repo))
+
+	manifestBytes, m, err := limits.fetchManifest(ctx, store, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	configBytes, err := limits.fetch(ctx, store, m.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config: %w", registryError(err))
+	}
+
+	layers := make([]LazyLayer, 0, len(m.Layers))
+	for _, layerDesc := range m.Layers {
+		layers = append(layers, LazyLayer{
+			MediaType: layerDesc.MediaType,
+			Digest:    string(layerDesc.Digest),
+			Size:      layerDesc.Size,
+			desc:      layerDesc,
+			store:     store,
+		})
+	}
+
+	return &LazyArtifact{
+		Manifest:     manifestBytes,
+		Config:       configBytes,
+		Layers:       layers,
+		Digest:       string(desc.Digest),
+		MediaType:    desc.MediaType,
+		ArtifactType: m.ArtifactType,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// ErrNoCredentialStore is returned by Login and Logout when the client has
+// no ClientOptions.Credentials.
+var ErrNoCredentialStore = errors.New("no credential store configured")
+
+// Login checks cred against the registry at host, exchanging it for a token
+// when the registry uses token authentication, and saves it to
+// ClientOptions.Credentials once the registry accepts it. Credentials the
+// registry rejects are not saved and the error wraps ErrUnauthorized.
+func (c *Client) Login(ctx context.Context, host string, cred auth.Credential) (err error) {
+	ctx, span := c.startSpan(ctx, "eigenruntime.Login", attribute.String("oci.registry", host))
+	defer func() { endSpan(span, err) }()
+
+	if c.opts.Credentials == nil {
+		return ErrNoCredentialStore
+	}
+
+	reg, err := remote.NewRegistry(host)
+	if err != nil {
+		return fmt.Errorf("invalid registry %q: %w", host, err)
+	}
+	client, err := c.authClient(reg.Reference.Registry, auth.StaticCredential(reg.Reference.Registry, cred))
+	if err != nil {
+		return err
+	}
+	reg.Client = client
+	reg.PlainHTTP = c.opts.PlainHTTP || c.opts.Registries[reg.Reference.Registry].PlainHTTP
+
+	if err := reg.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to log in to %s: %w", host, registryError(err))
+	}
+
+	if err := c.opts.Credentials.Put(ctx, reg.Reference.Registry, cred); err != nil {
+		return fmt.Errorf("failed to save credentials for %s: %w", host, err)
+	}
+	return nil
+}
+
+// Logout removes the credentials for the registry at host from
+// ClientOptions.Credentials. host is normalized the same way as in Login, so
+// it removes whatever Login saved for the same host.
+func (c *Client) Logout(ctx context.Context, host string) error {
+	if c.opts.Credentials == nil {
+		return ErrNoCredentialStore
+	}
+	reg, err := remote.NewRegistry(host)
+	if err != nil {
+		return fmt.Errorf("invalid registry %q: %w", host, err)
+	}
+	if err := c.opts.Credentials.Delete(ctx, reg.Reference.Registry); err != nil {
+		return fmt.Errorf("failed to remove credentials for %s: %w", host, err)
+	}
+	return nil
+}
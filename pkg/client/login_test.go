@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/credentials"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestLogin(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+	reg.RequireAuth("user", "pass")
+	ctx := context.Background()
+
+	store := credentials.NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+	c := NewClient(ClientOptions{PlainHTTP: true, Credentials: store})
+
+	if _, err := c.Pull(ctx, reference); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected anonymous pull to be unauthorized, got %v", err)
+	}
+
+	err := c.Login(ctx, reg.Host(), auth.Credential{Username: "user", Password: "wrong"})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected login with a wrong password to be unauthorized, got %v", err)
+	}
+	if cred, _ := store.Get(ctx, reg.Host()); cred != auth.EmptyCredential {
+		t.Fatalf("Expected rejected credentials not to be saved, got %+v", cred)
+	}
+
+	if err := c.Login(ctx, reg.Host(), auth.Credential{Username: "user", Password: "pass"}); err != nil {
+		t.Fatalf("Failed to log in: %v", err)
+	}
+
+	// A new client sharing the store uses the saved credentials for both
+	// pulls and pushes.
+	c = NewClient(ClientOptions{PlainHTTP: true, Credentials: store})
+	if _, err := c.Pull(ctx, reference); err != nil {
+		t.Fatalf("Failed to pull after login: %v", err)
+	}
+	specContent, err := c.FetchSpec(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to fetch spec: %v", err)
+	}
+	if _, err := c.Push(ctx, specContent, artifact.BuildOptions{}, reg.Host()+"/example/runtime:v1.0.1"); err != nil {
+		t.Fatalf("Failed to push after login: %v", err)
+	}
+
+	if err := c.Logout(ctx, reg.Host()); err != nil {
+		t.Fatalf("Failed to log out: %v", err)
+	}
+	if _, err := c.Pull(ctx, reference); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected pull after logout to be unauthorized, got %v", err)
+	}
+}
+
+func TestLoginWithoutStore(t *testing.T) {
+	c := NewClient(ClientOptions{})
+	if err := c.Login(context.Background(), "ghcr.io", auth.Credential{Username: "user"}); !errors.Is(err, ErrNoCredentialStore) {
+		t.Errorf("Expected ErrNoCredentialStore, got %v", err)
+	}
+	if err := c.Logout(context.Background(), "ghcr.io"); !errors.Is(err, ErrNoCredentialStore) {
+		t.Errorf("Expected ErrNoCredentialStore, got %v", err)
+	}
+}
+
+func TestLogoutInvalidHost(t *testing.T) {
+	c := NewClient(ClientOptions{Credentials: credentials.NewFileStore(filepath.Join(t.TempDir(), "config.json"))})
+	if err := c.Logout(context.Background(), "ghcr.io/example"); err == nil {
+		t.Error("Expected an error for a host with a repository path")
+	}
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"oras.land/oras-go/v2/content"
+)
+
+const tracerName = "github.com/Layr-Labs/eigenruntime-go/pkg/client"
+
+// Metrics receives measurements of registry traffic. Implementations are
+// called concurrently and must not block; adapters for Prometheus or
+// OpenTelemetry metrics only need to forward to their own instruments.
+type Metrics interface {
+	// ObserveRequest is called once per HTTP request attempt to host, with
+	// the response status (zero if no response was received), the time
+	// until the response headers arrived and any transport error.
+	ObserveRequest(host, method string, status int, duration time.Duration, err error)
+	// AddBytesSent records request body bytes uploaded to host.
+	AddBytesSent(host string, n int64)
+	// AddBytesReceived records response body bytes downloaded from host.
+	AddBytesReceived(host string, n int64)
+}
+
+func (c *Client) logger() *slog.Logger {
+	if c.opts.Logger != nil {
+		return c.opts.Logger
+	}
+	return discardLogger
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	provider := c.opts.TracerProvider
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return provider.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func descriptorAttributes(desc ocispec.Descriptor) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("oci.digest", desc.Digest.String()),
+		attribute.String("oci.media_type", desc.MediaType),
+		attribute.Int64("oci.size", desc.Size),
+	}
+}
+
+// tracedFetcher wraps a content source so that every blob fetch is logged
+// and covered by a span that lasts until the blob has been read and closed.
+type tracedFetcher struct {
+	content.Fetcher
+	client *Client
+}
+
+func (c *Client) traced(store content.Fetcher) content.Fetcher {
+	return &tracedFetcher{Fetcher: store, client: c}
+}
+
+func (f *tracedFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	ctx, span := f.client.startSpan(ctx, "eigenruntime.fetch", descriptorAttributes(desc)...)
+	f.client.logger().DebugContext(ctx, "fetching blob",
+		"digest", desc.Digest, "mediaType", desc.MediaType, "size", desc.Size)
+
+	rc, err := f.Fetcher.Fetch(ctx, desc)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+
+	return &tracedReadCloser{ReadCloser: rc, span: span}, nil
+}
+
+// tracedStorage is the content.ReadOnlyStorage counterpart of tracedFetcher,
+// for sources handed to oras.CopyGraph.
+type tracedStorage struct {
+	content.ReadOnlyStorage
+	fetcher content.Fetcher
+}
+
+func (c *Client) tracedStorage(store content.ReadOnlyStorage) content.ReadOnlyStorage {
+	return &tracedStorage{ReadOnlyStorage: store, fetcher: c.traced(store)}
+}
+
+func (s *tracedStorage) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	return s.fetcher.Fetch(ctx, desc)
+}
+
+type tracedReadCloser struct {
+	io.ReadCloser
+	span trace.Span
+	err  error
+}
+
+func (r *tracedReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+func (r *tracedReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	endSpan(r.span, r.err)
+	return err
+}
+
+// instrumentedTransport reports every request attempt to the client's
+// logger and metrics. It sits below the retry transport so that retried
+// attempts are observed individually.
+type instrumentedTransport struct {
+	base   http.RoundTripper
+	client *Client
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	metrics := t.client.opts.Metrics
+
+	if metrics != nil && req.ContentLength > 0 {
+		metrics.AddBytesSent(host, req.ContentLength)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+
+	logger := t.client.logger()
+	if err != nil {
+		logger.WarnContext(req.Context(), "registry request failed",
+			"method", req.Method, "url", req.URL.Redacted(), "duration", duration, "error", err)
+	} else {
+		logger.DebugContext(req.Context(), "registry request",
+			"method", req.Method, "url", req.URL.Redacted(), "status", status, "duration", duration)
+	}
+
+	if metrics != nil {
+		metrics.ObserveRequest(host, req.Method, status, duration, err)
+		if resp != nil && resp.Body != nil {
+			resp.Body = &countingReadCloser{ReadCloser: resp.Body, add: func(n int64) {
+				metrics.AddBytesReceived(host, n)
+			}}
+		}
+	}
+
+	return resp, err
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	add func(n int64)
+}
+
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.add(int64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
+	"github.com/opencontainers/go-digest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestObservability(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	var logs bytes.Buffer
+	metrics := &recordingMetrics{}
+	tracer := &recordingTracerProvider{}
+
+	c := NewClient(ClientOptions{
+		PlainHTTP:      true,
+		Retry:          fastRetry,
+		Logger:         slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		TracerProvider: tracer,
+		Metrics:        metrics,
+	})
+	ctx := context.Background()
+
+	reg.FailNext(registrytest.Failure{Status: http.StatusServiceUnavailable})
+	art, err := c.Pull(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+
+	if _, err := c.Push(ctx, []byte(fmt.Sprintf(registrytest.SpecYAML, reg.Host(), digest.FromString("performer"))), artifact.BuildOptions{}, reg.Host()+"/example/pushed:v1"); err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+
+	for _, want := range []string{"resolved reference", "fetching blob", art.Layers[0].Digest, "pushed artifact"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("Expected logs to contain %q", want)
+		}
+	}
+
+	host := reg.Host()
+	if metrics.received[host] < int64(len(art.Manifest)+len(art.Config)+len(art.Layers[0].Content)) {
+		t.Errorf("Expected received bytes to cover the artifact, got %d", metrics.received[host])
+	}
+	if metrics.sent[host] == 0 {
+		t.Error("Expected sent bytes to be recorded for push")
+	}
+	if metrics.errors[host] != 1 {
+		t.Errorf("Expected 1 failed request, got %d", metrics.errors[host])
+	}
+
+	for _, name := range []string{"eigenruntime.Pull", "eigenruntime.resolve", "eigenruntime.fetch", "eigenruntime.Push"} {
+		if tracer.count(name) == 0 {
+			t.Errorf("Expected a %s span", name)
+		}
+	}
+	if open := tracer.open(); open != 0 {
+		t.Errorf("Expected all spans to be ended, %d still open", open)
+	}
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	sent     map[string]int64
+	received map[string]int64
+	errors   map[string]int
+}
+
+func (m *recordingMetrics) ObserveRequest(host, method string, status int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.errors == nil {
+		m.errors = make(map[string]int)
+	}
+	if err != nil || status >= 500 {
+		m.errors[host]++
+	}
+}
+
+func (m *recordingMetrics) AddBytesSent(host string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sent == nil {
+		m.sent = make(map[string]int64)
+	}
+	m.sent[host] += n
+}
+
+func (m *recordingMetrics) AddBytesReceived(host string, n int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.received == nil {
+		m.received = make(map[string]int64)
+	}
+	m.received[host] += n
+}
+
+// recordingTracerProvider records the names of started spans and how many
+// of them have not been ended.
+type recordingTracerProvider struct {
+	noop.TracerProvider
+
+	mu      sync.Mutex
+	started map[string]int
+	ended   int
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return recordingTracer{provider: p}
+}
+
+func (p *recordingTracerProvider) count(name string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.started[name]
+}
+
+func (p *recordingTracerProvider) open() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	total := 0
+	for _, n := range p.started {
+		total += n
+	}
+	return total - p.ended
+}
+
+type recordingTracer struct {
+	noop.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t recordingTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.provider.mu.Lock()
+	defer t.provider.mu.Unlock()
+	if t.provider.started == nil {
+		t.provider.started = make(map[string]int)
+	}
+	t.provider.started[name]++
+	return ctx, &recordingSpan{provider: t.provider}
+}
+
+type recordingSpan struct {
+	noop.Span
+	provider *recordingTracerProvider
+}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.provider.mu.Lock()
+	defer s.provider.mu.Unlock()
+	s.provider.ended++
+}
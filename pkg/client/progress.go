@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+)
+
+// ProgressStatus describes what happened to a blob in a ProgressEvent.
+type ProgressStatus int
+
+const (
+	// ProgressStarted is reported when the transfer of a blob begins.
+	ProgressStarted ProgressStatus = iota
+	// ProgressTransferring is reported as content of a blob is read.
+	ProgressTransferring
+	// ProgressSkipped is reported instead of a transfer when the
+	// destination already has the blob.
+	ProgressSkipped
+	// ProgressCompleted is reported once a blob has been transferred.
+	ProgressCompleted
+)
+
+func (s ProgressStatus) String() string {
+	switch s {
+	case ProgressStarted:
+		return "started"
+	case ProgressTransferring:
+		return "transferring"
+	case ProgressSkipped:
+		return "skipped"
+	case ProgressCompleted:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressEvent reports the transfer state of one blob.
+type ProgressEvent struct {
+	Status     ProgressStatus
+	Descriptor ocispec.Descriptor
+	// BytesDone is the number of bytes of the blob transferred so far.
+	BytesDone int64
+	// BytesTotal is the size of the blob.
+	BytesTotal int64
+}
+
+// ProgressFunc receives progress events. Blobs are transferred concurrently,
+// so it may be called from several goroutines at once and must not block.
+type ProgressFunc func(ProgressEvent)
+
+// ProgressChannel returns a ProgressFunc that sends events to ch. Events are
+// dropped rather than stalling a transfer when ch is full.
+func ProgressChannel(ch chan<- ProgressEvent) ProgressFunc {
+	return func(event ProgressEvent) {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func (c *Client) reportProgress(status ProgressStatus, desc ocispec.Descriptor, done int64) {
+	c.opts.Progress(ProgressEvent{
+		Status:     status,
+		Descriptor: desc,
+		BytesDone:  done,
+		BytesTotal: desc.Size,
+	})
+}
+
+// progressCopyGraphOptions adds progress reporting to opts, keeping any hooks
+// already set. It pairs with copyProgress, which reports the bytes read from
+// the source of the copy.
+func (c *Client) progressCopyGraphOptions(opts oras.CopyGraphOptions) oras.CopyGraphOptions {
+	if c.opts.Progress == nil {
+		return opts
+	}
+
+	preCopy, postCopy, onSkipped := opts.PreCopy, opts.PostCopy, opts.OnCopySkipped
+
+	opts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		if preCopy != nil {
+			if err := preCopy(ctx, desc); err != nil {
+				return err
+			}
+		}
+		c.reportProgress(ProgressStarted, desc, 0)
+		return nil
+	}
+	opts.PostCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.reportProgress(ProgressCompleted, desc, desc.Size)
+		if postCopy != nil {
+			return postCopy(ctx, desc)
+		}
+		return nil
+	}
+	opts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.reportProgress(ProgressSkipped, desc, 0)
+		if onSkipped != nil {
+			return onSkipped(ctx, desc)
+		}
+		return nil
+	}
+
+	return opts
+}
+
+// copyProgress wraps the source of a copy so that bytes read from it are
+// reported. Start and completion are reported by progressCopyGraphOptions.
+func (c *Client) copyProgress(src oras.ReadOnlyTarget) oras.ReadOnlyTarget {
+	if c.opts.Progress == nil {
+		return src
+	}
+	return &copyProgressTarget{ReadOnlyTarget: src, client: c}
+}
+
+type copyProgressTarget struct {
+	oras.ReadOnlyTarget
+	client *Client
+}
+
+func (t *copyProgressTarget) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := t.ReadOnlyTarget.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	return &progressReadCloser{ReadCloser: rc, client: t.client, desc: desc}, nil
+}
+
+// fetchProgress wraps a store read directly, outside of a copy, so that the
+// start, bytes and completion of every fetched blob are reported.
+func (c *Client) fetchProgress(store content.Fetcher) content.Fetcher {
+	if c.opts.Progress == nil {
+		return store
+	}
+	return &fetchProgressFetcher{Fetcher: store, client: c}
+}
+
+type fetchProgressFetcher struct {
+	content.Fetcher
+	client *Client
+}
+
+func (f *fetchProgressFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := f.Fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	f.client.reportProgress(ProgressStarted, desc, 0)
+	return &progressReadCloser{ReadCloser: rc, client: f.client, desc: desc, complete: true}, nil
+}
+
+// fetchProgressStorage is the content.ReadOnlyStorage counterpart of
+// fetchProgress.
+func (c *Client) fetchProgressStorage(store content.ReadOnlyStorage) content.ReadOnlyStorage {
+	if c.opts.Progress == nil {
+		return store
+	}
+	return &fetchProgressStore{ReadOnlyStorage: store, fetcher: c.fetchProgress(store)}
+}
+
+type fetchProgressStore struct {
+	content.ReadOnlyStorage
+	fetcher content.Fetcher
+}
+
+func (s *fetchProgressStore) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	return s.fetcher.Fetch(ctx, desc)
+}
+
+type progressReadCloser struct {
+	io.ReadCloser
+	client *Client
+	desc   ocispec.Descriptor
+	done   int64
+	// complete reports ProgressCompleted when the end of the blob is read.
+	complete bool
+}
+
+func (r *progressReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.done += int64(n)
+		r.client.reportProgress(ProgressTransferring, r.desc, r.done)
+	}
+	if err == io.EOF && r.complete {
+		r.complete = false
+		r.client.reportProgress(ProgressCompleted, r.desc, r.done)
+	}
+	return n, err
+}
+
+func (r *progressReadCloser) Close() error {
+	if r.complete && r.done == r.desc.Size {
+		r.complete = false
+		r.client.reportProgress(ProgressCompleted, r.desc, r.done)
+	}
+	return r.ReadCloser.Close()
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
+	"github.com/opencontainers/go-digest"
+)
+
+func TestProgress(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+	ctx := context.Background()
+
+	t.Run("pull", func(t *testing.T) {
+		events := &progressRecorder{}
+		c := NewClient(ClientOptions{PlainHTTP: true, Progress: events.record})
+
+		art, err := c.Pull(ctx, reference)
+		if err != nil {
+			t.Fatalf("Failed to pull: %v", err)
+		}
+
+		// Manifest, config and spec layer.
+		events.expectCompleted(t, 3)
+		layer := digest.Digest(art.Layers[0].Digest)
+		if done := events.lastBytes(layer); done != art.Layers[0].Size {
+			t.Errorf("Expected %d bytes reported for layer, got %d", art.Layers[0].Size, done)
+		}
+	})
+
+	t.Run("cached pull", func(t *testing.T) {
+		events := &progressRecorder{}
+		c := NewClient(ClientOptions{PlainHTTP: true, CacheDir: t.TempDir(), Progress: events.record})
+
+		if _, err := c.Pull(ctx, reference); err != nil {
+			t.Fatalf("Failed to pull: %v", err)
+		}
+		events.expectCompleted(t, 3)
+	})
+
+	t.Run("lazy pull", func(t *testing.T) {
+		events := &progressRecorder{}
+		c := NewClient(ClientOptions{PlainHTTP: true, Progress: events.record})
+
+		lazy, err := c.PullLazy(ctx, reference)
+		if err != nil {
+			t.Fatalf("Failed to pull: %v", err)
+		}
+		events.expectCompleted(t, 2)
+
+		rc, err := lazy.Layers[0].Open(ctx)
+		if err != nil {
+			t.Fatalf("Failed to open layer: %v", err)
+		}
+		if _, err := io.Copy(io.Discard, rc); err != nil {
+			t.Fatalf("Failed to read layer: %v", err)
+		}
+		rc.Close()
+		events.expectCompleted(t, 3)
+	})
+
+	t.Run("push", func(t *testing.T) {
+		events := &progressRecorder{}
+		c := NewClient(ClientOptions{PlainHTTP: true, Progress: events.record})
+
+		target := reg.Host() + "/example/pushed:v1"
+		if _, err := c.Push(ctx, []byte(fmt.Sprintf(registrytest.SpecYAML, reg.Host(), digest.FromString("performer"))), artifact.BuildOptions{}, target); err != nil {
+			t.Fatalf("Failed to push: %v", err)
+		}
+		events.expectCompleted(t, 3)
+		if events.count(ProgressTransferring) == 0 {
+			t.Error("Expected byte progress while pushing")
+		}
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		dst := registrytest.New(t)
+		events := &progressRecorder{}
+		c := NewClient(ClientOptions{PlainHTTP: true, Progress: events.record})
+
+		if _, err := c.Copy(ctx, reference, dst.Host()+"/mirror/runtime:v1.0.0"); err != nil {
+			t.Fatalf("Failed to copy: %v", err)
+		}
+		events.expectCompleted(t, 3)
+
+		// Copying again within the destination skips every blob.
+		events = &progressRecorder{}
+		c = NewClient(ClientOptions{PlainHTTP: true, Progress: events.record})
+		if _, err := c.Copy(ctx, dst.Host()+"/mirror/runtime:v1.0.0", dst.Host()+"/mirror/runtime:stable"); err != nil {
+			t.Fatalf("Failed to copy: %v", err)
+		}
+		if events.count(ProgressSkipped) == 0 {
+			t.Error("Expected blobs present in the destination to be skipped")
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		events := &progressRecorder{}
+		c := NewClient(ClientOptions{PlainHTTP: true, Progress: events.record})
+
+		if err := c.Export(ctx, reference, io.Discard, ExportOptions{}); err != nil {
+			t.Fatalf("Failed to export: %v", err)
+		}
+		events.expectCompleted(t, 3)
+	})
+}
+
+func TestCopyRejectsMismatchedDigest(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	wrong := reg.Host() + "/example/copy@" + digest.FromString("other").String()
+	if _, err := c.Copy(context.Background(), reference, wrong); err == nil {
+		t.Error("Expected copy to a mismatched digest to fail")
+	}
+}
+
+type progressRecorder struct {
+	mu     sync.Mutex
+	events []ProgressEvent
+}
+
+func (r *progressRecorder) record(event ProgressEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *progressRecorder) count(status ProgressStatus) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, e := range r.events {
+		if e.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *progressRecorder) lastBytes(d digest.Digest) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var done int64
+	for _, e := range r.events {
+		if e.Descriptor.Digest == d {
+			done = e.BytesDone
+		}
+	}
+	return done
+}
+
+// expectCompleted checks that n distinct blobs were started and completed,
+// and that each completion reported the full size of its blob.
+func (r *progressRecorder) expectCompleted(t *testing.T, n int) {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	started := make(map[digest.Digest]bool)
+	completed := make(map[digest.Digest]bool)
+	for _, e := range r.events {
+		switch e.Status {
+		case ProgressStarted:
+			started[e.Descriptor.Digest] = true
+		case ProgressCompleted:
+			if !started[e.Descriptor.Digest] {
+				t.Errorf("Blob %s completed before it started", e.Descriptor.Digest)
+			}
+			if e.BytesDone != e.BytesTotal {
+				t.Errorf("Blob %s completed with %d of %d bytes", e.Descriptor.Digest, e.BytesDone, e.BytesTotal)
+			}
+			completed[e.Descriptor.Digest] = true
+		}
+	}
+
+	if len(started) != n || len(completed) != n {
+		t.Errorf("Expected %d blobs started and completed, got %d started and %d completed", n, len(started), len(completed))
+	}
+}
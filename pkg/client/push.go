@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/Masterminds/semver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// Push builds an artifact from specContent and pushes it to reference using
+// the client's registry settings. It returns the digest of the manifest.
+// Specs with a component still at spec.PlaceholderDigest are refused.
+func (c *Client) Push(ctx context.Context, specContent []byte, opts artifact.BuildOptions, reference string) (_ string, err error) {
+	ctx, span := c.startSpan(ctx, "eigenruntime.Push", attribute.String("oci.reference", reference))
+	defer func() { endSpan(span, err) }()
+
+	runtimeSpec, err := spec.ParseYAML(specContent)
+	if err != nil {
+		return "", err
+	}
+
+	store, manifestDesc, err := artifact.Build(ctx, specContent, opts)
+	if err != nil {
+		return "", err
+	}
+	span.SetAttributes(descriptorAttributes(manifestDesc)...)
+
+	if c.opts.AdmissionPolicy != nil {
+		if err := c.opts.AdmissionPolicy.Check(runtimeSpec); err != nil {
+			return "", fmt.Errorf("cannot publish %s: %w", reference, err)
+		}
+	}
+
+	if c.opts.UpgradePolicy != nil {
+		if err := c.checkPublish(ctx, runtimeSpec, reference); err != nil {
+			return "", err
+		}
+	}
+
+	repo, err := c.createRepository(reference)
+	if err != nil {
+		return "", err
+	}
+
+	copyOpts := oras.DefaultCopyGraphOptions
+	copyOpts.PreCopy = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.logger().DebugContext(ctx, "pushing blob",
+			"digest", desc.Digest, "mediaType", desc.MediaType, "size", desc.Size)
+		return nil
+	}
+	copyOpts.OnCopySkipped = func(ctx context.Context, desc ocispec.Descriptor) error {
+		c.logger().DebugContext(ctx, "blob already exists", "digest", desc.Digest)
+		return nil
+	}
+
+	copyOpts = c.progressCopyGraphOptions(copyOpts)
+	if err := artifact.Push(ctx, c.copyProgress(store), manifestDesc, repo, reference, copyOpts); err != nil {
+		return "", fmt.Errorf("failed to push %s: %w", reference, registryError(err))
+	}
+
+	c.logger().InfoContext(ctx, "pushed artifact", "reference", reference, "digest", manifestDesc.Digest)
+
+	return string(manifestDesc.Digest), nil
+}
+
+// CheckUpgrade fetches the specs of the deployed artifact currentRef and of
+// candidateRef and checks that the candidate may replace it under the
+// client's upgrade policy, or spec.DefaultUpgradePolicy if none is set. It
+// returns the changes between them; on a violation the error is a
+// *spec.UpgradeError.
+func (c *Client) CheckUpgrade(ctx context.Context, currentRef, candidateRef string) (spec.ChangeSet, error) {
+	current, err := c.fetchRuntimeSpec(ctx, currentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := c.fetchRuntimeSpec(ctx, candidateRef)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := c.opts.UpgradePolicy
+	if policy == nil {
+		policy = spec.DefaultUpgradePolicy()
+	}
+
+	return policy.Check(current, candidate)
+}
+
+// checkPublish checks candidate against the highest semantic version
+// published in the repository of reference with the same major version, so
+// that older major versions can still receive fixes. Publishing the first
+// version of a major version is always allowed.
+func (c *Client) checkPublish(ctx context.Context, candidate *common.RuntimeSpec, reference string) error {
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return fmt.Errorf("invalid reference: %w", err)
+	}
+	repository := ref.Registry + "/" + ref.Repository
+
+	// A candidate without a valid version is checked against the highest
+	// version overall, which the policy then rejects.
+	var constraint string
+	if v, err := semver.NewVersion(candidate.Version); err == nil {
+		constraint = fmt.Sprintf("%d.x", v.Major())
+	}
+
+	latest, err := c.LatestVersion(ctx, repository, constraint)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	current, err := c.fetchRuntimeSpec(ctx, repository+":"+latest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.opts.UpgradePolicy.Check(current, candidate); err != nil {
+		return fmt.Errorf("cannot publish %s over %s: %w", reference, latest, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+)
+
+func TestPushRoundTrip(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.Host() + "/example/runtime:v1.0.0"
+	specContent := []byte("apiVersion: eigenruntime.io/v1alpha1\nkind: Runtime\nname: pushed\n")
+
+	c := NewClient(ClientOptions{PlainHTTP: true, Retry: fastRetry})
+	ctx := context.Background()
+
+	reg.FailNext(registrytest.Failure{Status: http.StatusServiceUnavailable})
+	digest, err := c.Push(ctx, specContent, artifact.BuildOptions{Description: "pushed"}, reference)
+	if err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+
+	art, err := c.Pull(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to pull pushed artifact: %v", err)
+	}
+
+	if art.Digest != digest {
+		t.Errorf("Expected digest %s, got %s", digest, art.Digest)
+	}
+	if art.ArtifactType != common.MediaTypeEigenRuntimeManifest {
+		t.Errorf("Expected artifact type %s, got %s", common.MediaTypeEigenRuntimeManifest, art.ArtifactType)
+	}
+	if string(art.Layers[0].Content) != string(specContent) {
+		t.Errorf("Expected spec %q, got %q", specContent, art.Layers[0].Content)
+	}
+}
+
+func TestPushUpgradePolicy(t *testing.T) {
+	reg := registrytest.New(t)
+	repository := reg.Host() + "/example/runtime"
+	specAt := func(version string) []byte {
+		return []byte("apiVersion: eigenruntime.io/v1alpha1\nkind: Runtime\nname: example\nversion: " + version + "\n")
+	}
+
+	c := NewClient(ClientOptions{PlainHTTP: true, UpgradePolicy: spec.DefaultUpgradePolicy()})
+	ctx := context.Background()
+
+	if _, err := c.Push(ctx, specAt("v1.0.0"), artifact.BuildOptions{}, repository+":v1.0.0"); err != nil {
+		t.Fatalf("Expected first publish to be allowed: %v", err)
+	}
+	if _, err := c.Push(ctx, specAt("v1.1.0"), artifact.BuildOptions{}, repository+":v1.1.0"); err != nil {
+		t.Fatalf("Expected upgrade to be allowed: %v", err)
+	}
+
+	_, err := c.Push(ctx, specAt("v1.0.5"), artifact.BuildOptions{}, repository+":v1.0.5")
+	var upgradeErr *spec.UpgradeError
+	if !errors.As(err, &upgradeErr) {
+		t.Fatalf("Expected *spec.UpgradeError, got %v", err)
+	}
+	if _, err := c.Resolve(ctx, repository+":v1.0.5"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected rejected artifact not to be pushed, got %v", err)
+	}
+
+	changes, err := c.CheckUpgrade(ctx, repository+":v1.0.0", repository+":v1.1.0")
+	if err != nil {
+		t.Errorf("Expected v1.0.0 -> v1.1.0 to be allowed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Path != "version" {
+		t.Errorf("Expected a version change, got %v", changes)
+	}
+	if _, err := c.CheckUpgrade(ctx, repository+":v1.1.0", repository+":v1.0.0"); !errors.As(err, &upgradeErr) {
+		t.Errorf("Expected v1.1.0 -> v1.0.0 to be rejected, got %v", err)
+	}
+}
+
+func TestPushUpgradePolicyHotfix(t *testing.T) {
+	reg := registrytest.New(t)
+	repository := reg.Host() + "/example/runtime"
+	specAt := func(version string) []byte {
+		return []byte("apiVersion: eigenruntime.io/v1alpha1\nkind: Runtime\nname: example\nversion: " + version + "\n")
+	}
+
+	c := NewClient(ClientOptions{PlainHTTP: true, UpgradePolicy: spec.DefaultUpgradePolicy()})
+	ctx := context.Background()
+
+	for _, version := range []string{"1.4.2", "2.0.0", "1.4.3"} {
+		if _, err := c.Push(ctx, specAt(version), artifact.BuildOptions{}, repository+":"+version); err != nil {
+			t.Fatalf("Expected %s to be allowed: %v", version, err)
+		}
+	}
+
+	// 1.4.1 is checked against 1.4.3, not 2.0.0.
+	_, err := c.Push(ctx, specAt("1.4.1"), artifact.BuildOptions{}, repository+":1.4.1")
+	var upgradeErr *spec.UpgradeError
+	if !errors.As(err, &upgradeErr) || !strings.Contains(err.Error(), "over 1.4.3") {
+		t.Errorf("Expected 1.4.1 to be rejected against 1.4.3, got %v", err)
+	}
+}
+
+func TestPushAdmissionPolicy(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.Host() + "/example/runtime:v1.0.0"
+
+	policy, err := spec.ParseAdmissionPolicy([]byte(`
+rules:
+  - name: trusted-registries
+    forEach: components
+    require: component.registry startsWith "ghcr.io/our-org/"
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse policy: %v", err)
+	}
+
+	c := NewClient(ClientOptions{PlainHTTP: true, AdmissionPolicy: policy})
+	specContent := []byte(fmt.Sprintf(registrytest.SpecYAML, "docker.io/someone", "sha256:abc"))
+	_, err = c.Push(context.Background(), specContent, artifact.BuildOptions{}, reference)
+
+	var validationErr *spec.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *spec.ValidationError, got %v", err)
+	}
+	if len(reg.Requests()) != 0 {
+		t.Errorf("Expected nothing to be sent to the registry, got %v", reg.Requests())
+	}
+}
+
+func TestPushPlaceholderDigest(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.Host() + "/example/runtime:v0.1.0"
+
+	runtimeSpec, err := spec.Scaffold(context.Background(), spec.ScaffoldOptions{Name: "my-avs"})
+	if err != nil {
+		t.Fatalf("Failed to scaffold spec: %v", err)
+	}
+	specContent, err := spec.ToYAML(runtimeSpec)
+	if err != nil {
+		t.Fatalf("Failed to marshal spec: %v", err)
+	}
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	_, err = c.Push(context.Background(), specContent, artifact.BuildOptions{}, reference)
+
+	var validationErr *spec.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *spec.ValidationError, got %v", err)
+	}
+	if len(reg.Requests()) != 0 {
+		t.Errorf("Expected nothing to be sent to the registry, got %v", reg.Requests())
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// DefaultRetryPolicy is used when ClientOptions.Retry is nil.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Jitter:         0.2,
+}
+
+// RetryPolicy controls how registry requests are retried. Requests are
+// retried on network timeouts and on 408, 429 and 5xx responses, waiting an
+// exponentially growing backoff between attempts. A Retry-After header sent
+// with a 429 or 503 response takes precedence over the computed backoff;
+// if it asks for a longer wait than MaxBackoff, the request is not retried
+// and fails with the registry's error.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per request, including the
+	// first one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. It doubles with
+	// every further attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter randomizes each backoff by up to this fraction in either
+	// direction, e.g. 0.2 for ±20%.
+	Jitter float64
+}
+
+// Retry implements retry.Policy.
+func (p RetryPolicy) Retry(attempt int, resp *http.Response, err error) (time.Duration, error) {
+	if attempt+1 >= p.MaxAttempts {
+		return -1, nil
+	}
+
+	if ok, err := retry.DefaultPredicate(resp, err); err != nil || !ok {
+		return -1, err
+	}
+
+	if wait, ok := retryAfter(resp); ok {
+		if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+			return -1, nil
+		}
+		return wait, nil
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(2, float64(attempt))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(backoff), nil
+}
+
+// retryAfter parses the Retry-After header of a rate-limited or unavailable
+// response, which may hold either a number of seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func (c *Client) retryPolicy() retry.Policy {
+	if c.opts.Retry == nil {
+		return DefaultRetryPolicy
+	}
+	return *c.opts.Retry
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+)
+
+var fastRetry = &RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+func TestPullRetriesTransientFailures(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true, Retry: fastRetry})
+
+	reg.FailNext(
+		registrytest.Failure{Status: http.StatusServiceUnavailable},
+		registrytest.Failure{Status: http.StatusTooManyRequests, RetryAfter: "0"},
+	)
+	if _, err := c.Pull(context.Background(), reference); err != nil {
+		t.Fatalf("Expected pull to succeed after retries: %v", err)
+	}
+}
+
+func TestPullErrorClassification(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true, Retry: fastRetry})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		failures []registrytest.Failure
+		ref      string
+		expected error
+	}{
+		{
+			name:     "retries exhausted",
+			failures: []registrytest.Failure{{Status: 502}, {Status: 503}, {Status: 504}},
+			ref:      reference,
+			expected: ErrTransient,
+		},
+		{
+			name:     "retry-after beyond max backoff",
+			failures: []registrytest.Failure{{Status: http.StatusTooManyRequests, RetryAfter: "60"}},
+			ref:      reference,
+			expected: ErrTransient,
+		},
+		{
+			name:     "unauthorized",
+			failures: []registrytest.Failure{{Status: http.StatusUnauthorized}},
+			ref:      reference,
+			expected: ErrUnauthorized,
+		},
+		{
+			name:     "forbidden",
+			failures: []registrytest.Failure{{Status: http.StatusForbidden}},
+			ref:      reference,
+			expected: ErrUnauthorized,
+		},
+		{
+			name:     "not found",
+			ref:      reg.Host() + "/example/runtime:missing",
+			expected: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg.FailNext(tt.failures...)
+
+			_, err := c.Pull(ctx, tt.ref)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+	}
+
+	response := func(status int, retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		resp     *http.Response
+		expected time.Duration
+	}{
+		{name: "first retry", attempt: 0, resp: response(500, ""), expected: 100 * time.Millisecond},
+		{name: "backoff doubles", attempt: 1, resp: response(502, ""), expected: 200 * time.Millisecond},
+		{name: "backoff is capped", attempt: 2, resp: response(503, ""), expected: 300 * time.Millisecond},
+		{name: "attempts exhausted", attempt: 3, resp: response(503, ""), expected: -1},
+		{name: "not retryable", attempt: 0, resp: response(404, ""), expected: -1},
+		{name: "retry-after seconds", attempt: 0, resp: response(429, "0"), expected: 0},
+		{name: "retry-after beyond max backoff", attempt: 0, resp: response(429, "7"), expected: -1},
+		{name: "retry-after ignored on 500", attempt: 0, resp: response(500, "7"), expected: 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, err := policy.Retry(tt.attempt, tt.resp, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if wait != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, wait)
+			}
+		})
+	}
+
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	wait, _ := policy.Retry(0, response(503, date), nil)
+	if wait != -1 {
+		t.Errorf("Expected a Retry-After date beyond MaxBackoff to stop retries, got %v", wait)
+	}
+
+	policy.MaxBackoff = 0
+	wait, _ = policy.Retry(0, response(503, date), nil)
+	if wait < 59*time.Minute || wait > time.Hour {
+		t.Errorf("Expected Retry-After date to be honoured without MaxBackoff, got %v", wait)
+	}
+}
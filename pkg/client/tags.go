@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Masterminds/semver/v3"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+// ErrDeleteUnsupported is returned by Delete when the registry refuses
+// manifest deletion, which many hosted registries disable by default.
+var ErrDeleteUnsupported = errors.New("registry does not support deletion")
+
+// Resolve resolves reference to the descriptor of its manifest without
+// downloading any content.
+func (c *Client) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	_, desc, err := c.resolveRepository(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to resolve %s: %w", reference, registryError(err))
+	}
+
+	return desc, nil
+}
+
+// resolveRepository resolves reference against the configured mirrors and
+// then its registry, returning the first repository that has it.
+func (c *Client) resolveRepository(ctx context.Context, reference string) (repo *remote.Repository, desc ocispec.Descriptor, err error) {
+	ctx, span := c.startSpan(ctx, "eigenruntime.resolve", attribute.String("oci.reference", reference))
+	defer func() { endSpan(span, err) }()
+
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, fmt.Errorf("invalid reference: %w", err)
+	}
+
+	err = c.withMirrors(reference, func(candidate *remote.Repository) error {
+		d, err := candidate.Resolve(ctx, ref.Reference)
+		if err != nil {
+			c.logger().DebugContext(ctx, "resolve failed", "reference", reference,
+				"registry", candidate.Reference.Registry, "error", err)
+			return err
+		}
+		repo, desc = candidate, d
+		return nil
+	})
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+
+	span.SetAttributes(append(descriptorAttributes(desc), attribute.String("oci.registry", repo.Reference.Registry))...)
+	c.logger().DebugContext(ctx, "resolved reference", "reference", reference,
+		"registry", repo.Reference.Registry, "digest", desc.Digest)
+
+	return repo, desc, nil
+}
+
+// ListTags returns every tag in repository, following the registry's
+// pagination until the list is exhausted.
+func (c *Client) ListTags(ctx context.Context, repository string) ([]string, error) {
+	var tags []string
+	err := c.withMirrors(repository, func(repo *remote.Repository) error {
+		tags = nil
+		return repo.Tags(ctx, "", func(page []string) error {
+			tags = append(tags, page...)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", registryError(err))
+	}
+
+	return tags, nil
+}
+
+// LatestVersion returns the tag in repository with the highest semantic
+// version satisfying constraint, e.g. "^1.2" or ">= 1.0, < 2.0". An empty
+// constraint matches any release version. Tags that are not valid semantic
+// versions, such as "latest", are ignored.
+func (c *Client) LatestVersion(ctx context.Context, repository, constraint string) (string, error) {
+	if constraint == "" {
+		constraint = "*"
+	}
+
+	constraints, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint: %w", err)
+	}
+
+	tags, err := c.ListTags(ctx, repository)
+	if err != nil {
+		return "", err
+	}
+
+	var latestTag string
+	var latest *semver.Version
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil {
+			continue
+		}
+
+		if !constraints.Check(v) {
+			continue
+		}
+
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+			latestTag = tag
+		}
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("%w: no tag in %s satisfies %q", ErrNotFound, repository, constraint)
+	}
+
+	return latestTag, nil
+}
+
+// Tag adds newTags to the manifest referenced by srcRef. The manifest is
+// re-pushed under each tag in the same repository; blobs are not uploaded
+// again.
+func (c *Client) Tag(ctx context.Context, srcRef string, newTags ...string) error {
+	if len(newTags) == 0 {
+		return fmt.Errorf("at least one tag is required")
+	}
+	// Validate every tag up front so bad input never leaves the manifest
+	// with only some of the tags.
+	for _, tag := range newTags {
+		if tag == "" {
+			return fmt.Errorf("tag cannot be empty")
+		}
+		if err := (registry.Reference{Reference: tag}).ValidateReferenceAsTag(); err != nil {
+			return fmt.Errorf("invalid tag %q: %w", tag, err)
+		}
+	}
+
+	repo, err := c.createRepository(srcRef)
+	if err != nil {
+		return err
+	}
+
+	desc, err := repo.Resolve(ctx, srcRef)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", srcRef, registryError(err))
+	}
+
+	for _, tag := range newTags {
+		if err := repo.Tag(ctx, desc, tag); err != nil {
+			return fmt.Errorf("failed to tag %s as %s: %w", srcRef, tag, registryError(err))
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the manifest referenced by reference. Tag references are
+// resolved first, so the manifest is deleted by digest and every tag pointing
+// at it disappears with it. Blobs are left to the registry's garbage
+// collection.
+func (c *Client) Delete(ctx context.Context, reference string) error {
+	repo, err := c.createRepository(reference)
+	if err != nil {
+		return err
+	}
+
+	desc, err := repo.Resolve(ctx, reference)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", reference, registryError(err))
+	}
+
+	if err := repo.Manifests().Delete(ctx, desc); err != nil {
+		if isDeleteUnsupported(err) {
+			return fmt.Errorf("failed to delete %s: %w: %w", reference, ErrDeleteUnsupported, registryError(err))
+		}
+		return fmt.Errorf("failed to delete %s: %w", reference, registryError(err))
+	}
+
+	return nil
+}
+
+func isDeleteUnsupported(err error) bool {
+	var errResp *errcode.ErrorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+
+	if errResp.StatusCode == http.StatusMethodNotAllowed {
+		return true
+	}
+
+	for _, e := range errResp.Errors {
+		if e.Code == errcode.ErrorCodeUnsupported {
+			return true
+		}
+	}
+
+	return false
+}
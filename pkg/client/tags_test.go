@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+)
+
+func TestResolve(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+
+	desc, err := c.Resolve(context.Background(), reference)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+
+	for _, req := range reg.Requests() {
+		if req != "GET /v2/" && !strings.HasPrefix(req, "HEAD ") {
+			t.Errorf("Expected resolve to avoid downloading content, got %s", req)
+		}
+	}
+
+	art, err := c.Pull(context.Background(), reference)
+	if err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+	if string(desc.Digest) != art.Digest {
+		t.Errorf("Expected digest %s, got %s", art.Digest, desc.Digest)
+	}
+}
+
+func TestListTagsPaginates(t *testing.T) {
+	reg := registrytest.New(t)
+	expected := []string{"latest", "v1.0.0", "v1.1.0", "v1.2.0", "v2.0.0"}
+	for _, tag := range expected {
+		reg.PutArtifact(t, "example/runtime", tag, []byte("name: "+tag))
+	}
+
+	c := NewClient(ClientOptions{PlainHTTP: true, TagListPageSize: 2})
+
+	tags, err := c.ListTags(context.Background(), reg.Host()+"/example/runtime")
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+
+	sort.Strings(tags)
+	if len(tags) != len(expected) {
+		t.Fatalf("Expected %d tags, got %v", len(expected), tags)
+	}
+	for i := range expected {
+		if tags[i] != expected[i] {
+			t.Errorf("Expected tag %s, got %s", expected[i], tags[i])
+		}
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	reg := registrytest.New(t)
+	for _, tag := range []string{"latest", "rc", "v1.1.0", "v1.2.0", "v1.2.5", "v1.3.0-rc.1", "v1.10.0", "v2.0.0"} {
+		reg.PutArtifact(t, "example/runtime", tag, []byte("name: "+tag))
+	}
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	repository := reg.Host() + "/example/runtime"
+
+	tests := []struct {
+		constraint string
+		expected   string
+		wantErr    bool
+	}{
+		{constraint: "", expected: "v2.0.0"},
+		{constraint: "^1.2", expected: "v1.10.0"},
+		{constraint: "~1.2", expected: "v1.2.5"},
+		{constraint: ">= 1.0, < 1.2", expected: "v1.1.0"},
+		{constraint: "^3", wantErr: true},
+		{constraint: "not a constraint", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			tag, err := c.LatestVersion(context.Background(), repository, tt.constraint)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got tag %s", tag)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to find latest version: %v", err)
+			}
+			if tag != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, tag)
+			}
+		})
+	}
+}
+
+func TestTag(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "rc")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	ctx := context.Background()
+
+	if err := c.Tag(ctx, reference, "v1.2.0", "stable"); err != nil {
+		t.Fatalf("Failed to tag: %v", err)
+	}
+
+	for _, req := range reg.Requests() {
+		if strings.Contains(req, "/blobs/") {
+			t.Errorf("Expected tagging not to touch blobs, got %s", req)
+		}
+	}
+
+	src, err := c.Resolve(ctx, reference)
+	if err != nil {
+		t.Fatalf("Failed to resolve source: %v", err)
+	}
+	for _, tag := range []string{"v1.2.0", "stable"} {
+		desc, err := c.Resolve(ctx, reg.Host()+"/example/runtime:"+tag)
+		if err != nil {
+			t.Fatalf("Failed to resolve %s: %v", tag, err)
+		}
+		if desc.Digest != src.Digest {
+			t.Errorf("Expected %s to point at %s, got %s", tag, src.Digest, desc.Digest)
+		}
+	}
+
+	if err := c.Tag(ctx, reference); err == nil {
+		t.Error("Expected error when no tags are given")
+	}
+
+	for _, tags := range [][]string{{"partial", ""}, {"partial", "not a tag"}} {
+		if err := c.Tag(ctx, reference, tags...); err == nil {
+			t.Errorf("Expected error for tags %q", tags)
+		}
+	}
+	if _, err := c.Resolve(ctx, reg.Host()+"/example/runtime:partial"); err == nil {
+		t.Error("Expected no tag to be pushed when one of them is invalid")
+	}
+}
+
+func TestDelete(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	ctx := context.Background()
+
+	reg.SetDeleteDisabled(true)
+	err := c.Delete(ctx, reference)
+	if !errors.Is(err, ErrDeleteUnsupported) {
+		t.Fatalf("Expected ErrDeleteUnsupported, got %v", err)
+	}
+
+	reg.SetDeleteDisabled(false)
+	if err := c.Delete(ctx, reference); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	if _, err := c.Resolve(ctx, reference); err == nil {
+		t.Error("Expected deleted manifest to be unresolvable")
+	}
+}
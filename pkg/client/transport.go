@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/credentials"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/retry"
+)
+
+// TLSConfig configures transport security for a registry.
+type TLSConfig struct {
+	// CAFile is a PEM bundle of certificate authorities trusted in addition
+	// to the system roots.
+	CAFile string
+	// CertFile and KeyFile hold a PEM client certificate and private key
+	// presented for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// RegistryConfig holds settings for one registry host, in the spirit of a
+// [[registry]] entry in containers-registries.conf.
+type RegistryConfig struct {
+	// PlainHTTP talks to the registry over HTTP instead of HTTPS.
+	PlainHTTP bool
+	// TLS overrides ClientOptions.TLS for this registry.
+	TLS *TLSConfig
+	// Mirrors are tried in order by read operations before the registry
+	// itself, which remains the final fallback. Writes always go to the
+	// registry.
+	Mirrors []Mirror
+}
+
+// Mirror is a pull-through mirror of a registry.
+type Mirror struct {
+	// Location is the mirror host, optionally followed by a path prefix
+	// under which it serves the mirrored repositories, e.g.
+	// "mirror.internal:5000/ghcr". A repository "org/app" of the mirrored
+	// registry is then looked up as "mirror.internal:5000/ghcr/org/app".
+	Location string
+	// PlainHTTP talks to the mirror over HTTP instead of HTTPS.
+	PlainHTTP bool
+}
+
+// createRepository returns a repository for reference on its own registry,
+// ignoring mirrors.
+func (c *Client) createRepository(reference string) (*remote.Repository, error) {
+	repo, err := remote.NewRepository(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	host := repo.Reference.Registry
+	if err := c.configureRepository(repo, c.opts.PlainHTTP || c.opts.Registries[host].PlainHTTP); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// candidateRepositories returns the repositories a read of reference should
+// try, in order: each configured mirror, then the registry itself.
+func (c *Client) candidateRepositories(reference string) ([]*remote.Repository, error) {
+	origin, err := c.createRepository(reference)
+	if err != nil {
+		return nil, err
+	}
+
+	mirrors := c.opts.Registries[origin.Reference.Registry].Mirrors
+	repos := make([]*remote.Repository, 0, len(mirrors)+1)
+	for _, mirror := range mirrors {
+		location := strings.TrimSuffix(mirror.Location, "/")
+		repo, err := remote.NewRepository(location + "/" + origin.Reference.Repository)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mirror %q: %w", mirror.Location, err)
+		}
+		if err := c.configureRepository(repo, mirror.PlainHTTP || c.opts.Registries[repo.Reference.Registry].PlainHTTP); err != nil {
+			return nil, err
+		}
+		repos = append(repos, repo)
+	}
+
+	return append(repos, origin), nil
+}
+
+// withMirrors calls fn with each candidate repository for reference until
+// it succeeds, returning the error from the registry itself if every
+// candidate fails.
+func (c *Client) withMirrors(reference string, fn func(repo *remote.Repository) error) error {
+	repos, err := c.candidateRepositories(reference)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		if err = fn(repo); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (c *Client) configureRepository(repo *remote.Repository, plainHTTP bool) error {
+	client, err := c.authClient(repo.Reference.Registry, credentials.Func(c.opts.Credentials))
+	if err != nil {
+		return err
+	}
+
+	repo.Client = client
+	repo.PlainHTTP = plainHTTP
+	repo.TagListPageSize = c.opts.TagListPageSize
+
+	return nil
+}
+
+// authClient returns an HTTP client for host that authenticates with the
+// credentials returned by credential.
+func (c *Client) authClient(host string, credential func(context.Context, string) (auth.Credential, error)) (*auth.Client, error) {
+	transport, err := c.transport(host)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.Client{
+		Client: &http.Client{
+			Transport: &retry.Transport{
+				Base:   &instrumentedTransport{base: transport, client: c},
+				Policy: c.retryPolicy,
+			},
+		},
+		Credential: credential,
+	}, nil
+}
+
+// transport returns the HTTP transport for host, building it on first use
+// so that connections are pooled across operations.
+func (c *Client) transport(host string) (http.RoundTripper, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t, ok := c.transports[host]; ok {
+		return t, nil
+	}
+
+	tlsOpts := c.opts.TLS
+	if cfg, ok := c.opts.Registries[host]; ok && cfg.TLS != nil {
+		tlsOpts = cfg.TLS
+	}
+
+	tlsConfig, err := tlsOpts.build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration for %s: %w", host, err)
+	}
+
+	proxy, err := c.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = tlsConfig
+	t.Proxy = proxy
+
+	if c.transports == nil {
+		c.transports = make(map[string]http.RoundTripper)
+	}
+	c.transports[host] = t
+
+	return t, nil
+}
+
+func (c *Client) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if c.opts.ProxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(c.opts.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if matchesNoProxy(req.URL.Hostname(), c.opts.NoProxy) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}, nil
+}
+
+// matchesNoProxy reports whether host is excluded from proxying. Entries
+// match the host exactly, or any subdomain when they start with a dot.
+func matchesNoProxy(host string, noProxy []string) bool {
+	for _, entry := range noProxy {
+		if entry == "*" || host == entry {
+			return true
+		}
+		if strings.HasPrefix(entry, ".") && (strings.HasSuffix(host, entry) || host == entry[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *TLSConfig) build() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if t == nil {
+		return config, nil
+	}
+
+	config.InsecureSkipVerify = t.InsecureSkipVerify
+
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", t.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
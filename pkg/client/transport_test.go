@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+)
+
+func TestTLSConfiguration(t *testing.T) {
+	reg := registrytest.NewUnstarted(t)
+	reg.StartTLS()
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", reg.Certificate().Raw)
+
+	tests := []struct {
+		name    string
+		opts    ClientOptions
+		wantErr bool
+	}{
+		{name: "untrusted", opts: ClientOptions{}, wantErr: true},
+		{name: "global CA", opts: ClientOptions{TLS: &TLSConfig{CAFile: caFile}}},
+		{name: "per-host CA", opts: ClientOptions{Registries: map[string]RegistryConfig{
+			reg.Host(): {TLS: &TLSConfig{CAFile: caFile}},
+		}}},
+		{name: "per-host skip verify", opts: ClientOptions{Registries: map[string]RegistryConfig{
+			reg.Host(): {TLS: &TLSConfig{InsecureSkipVerify: true}},
+		}}},
+		{name: "other host skip verify", opts: ClientOptions{Registries: map[string]RegistryConfig{
+			"example.com": {TLS: &TLSConfig{InsecureSkipVerify: true}},
+		}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Retry = &RetryPolicy{MaxAttempts: 1}
+			_, err := NewClient(tt.opts).Pull(context.Background(), reference)
+			if tt.wantErr && err == nil {
+				t.Error("Expected pull to fail")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Failed to pull: %v", err)
+			}
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	clientCert := generateClientCertificate(t, certFile, keyFile)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCert)
+
+	reg := registrytest.NewUnstarted(t)
+	reg.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	reg.StartTLS()
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	noRetry := &RetryPolicy{MaxAttempts: 1}
+
+	if _, err := NewClient(ClientOptions{
+		Retry: noRetry,
+		TLS:   &TLSConfig{InsecureSkipVerify: true},
+	}).Pull(context.Background(), reference); err == nil {
+		t.Error("Expected pull without client certificate to fail")
+	}
+
+	if _, err := NewClient(ClientOptions{
+		Retry: noRetry,
+		TLS:   &TLSConfig{InsecureSkipVerify: true, CertFile: certFile, KeyFile: keyFile},
+	}).Pull(context.Background(), reference); err != nil {
+		t.Errorf("Failed to pull with client certificate: %v", err)
+	}
+}
+
+func TestMirrorFallback(t *testing.T) {
+	origin := registrytest.New(t)
+	reference := origin.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	mirror := registrytest.New(t)
+	mirror.PutRuntime(t, "ghcr/example/runtime", "v1.0.0")
+
+	broken := registrytest.New(t)
+	broken.Close()
+
+	c := NewClient(ClientOptions{
+		PlainHTTP: true,
+		Retry:     &RetryPolicy{MaxAttempts: 1},
+		Registries: map[string]RegistryConfig{
+			origin.Host(): {Mirrors: []Mirror{
+				{Location: broken.Host(), PlainHTTP: true},
+				{Location: mirror.Host() + "/ghcr", PlainHTTP: true},
+			}},
+		},
+	})
+
+	if _, err := c.Pull(context.Background(), reference); err != nil {
+		t.Fatalf("Failed to pull through mirror: %v", err)
+	}
+	if len(origin.Requests()) != 0 {
+		t.Errorf("Expected origin not to be contacted, got %v", origin.Requests())
+	}
+
+	missing := origin.Host() + "/example/other:v1.0.0"
+	origin.PutRuntime(t, "example/other", "v1.0.0")
+	if _, err := c.Pull(context.Background(), missing); err != nil {
+		t.Fatalf("Expected fallback to origin: %v", err)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "v1.0.0")
+
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(&httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			proxied.Add(1)
+			r.Out.URL = r.In.URL
+		},
+	})
+	defer proxy.Close()
+
+	c := NewClient(ClientOptions{PlainHTTP: true, ProxyURL: proxy.URL})
+	if _, err := c.Pull(context.Background(), reference); err != nil {
+		t.Fatalf("Failed to pull through proxy: %v", err)
+	}
+	if proxied.Load() == 0 {
+		t.Error("Expected requests to go through the proxy")
+	}
+
+	proxied.Store(0)
+	c = NewClient(ClientOptions{PlainHTTP: true, ProxyURL: proxy.URL, NoProxy: []string{"127.0.0.1"}})
+	if _, err := c.Pull(context.Background(), reference); err != nil {
+		t.Fatalf("Failed to pull bypassing proxy: %v", err)
+	}
+	if proxied.Load() != 0 {
+		t.Errorf("Expected NoProxy host to bypass the proxy, got %d proxied requests", proxied.Load())
+	}
+}
+
+func generateClientCertificate(t *testing.T, certFile, keyFile string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "eigenruntime-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"oras.land/oras-go/v2/registry"
+)
+
+// DefaultWatchInterval is the polling interval used when
+// WatchOptions.Interval is zero.
+const DefaultWatchInterval = 30 * time.Second
+
+// WatchOptions controls a Watcher.
+type WatchOptions struct {
+	// Interval is the time between tag resolutions. Zero applies
+	// DefaultWatchInterval.
+	Interval time.Duration
+}
+
+// WatchEvent reports that the watched tag moved, or that polling it failed.
+type WatchEvent struct {
+	Reference string
+	// OldDigest and NewDigest are the manifest digests before and after the
+	// tag moved.
+	OldDigest string
+	NewDigest string
+	// OldSpec and NewSpec are the runtime specs of the old and new
+	// artifacts, and Changes the differences between them.
+	OldSpec *common.RuntimeSpec
+	NewSpec *common.RuntimeSpec
+	Changes spec.ChangeSet
+	// Err is set, and every other field but Reference is empty, when the
+	// tag could not be resolved or the new artifact could not be fetched.
+	// The watcher keeps polling after an error.
+	Err error
+}
+
+// Watcher polls a tag and notifies subscribers when it points at a new
+// manifest. Each poll is a single HEAD request; the artifact is only
+// downloaded once the digest changes.
+type Watcher struct {
+	client    *Client
+	reference string
+	interval  time.Duration
+
+	mu          sync.Mutex
+	subscribers map[*watchSubscriber]struct{}
+	closed      bool
+
+	startOnce sync.Once
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+
+	digest string
+	spec   *common.RuntimeSpec
+}
+
+type watchSubscriber struct {
+	events   chan WatchEvent
+	gone     chan struct{}
+	goneOnce sync.Once
+}
+
+// Watch returns a Watcher for reference. Polling begins with Start.
+func (c *Client) Watch(reference string, opts WatchOptions) *Watcher {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	return &Watcher{
+		client:      c,
+		reference:   reference,
+		interval:    interval,
+		subscribers: make(map[*watchSubscriber]struct{}),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving every subsequent event and a
+// function that cancels the subscription. Events are delivered to each
+// subscriber in order; a subscriber that stops reading without cancelling
+// holds up delivery to the others. The channel is closed when the watcher
+// stops; cancelling the subscription stops delivery without closing it.
+func (w *Watcher) Subscribe() (<-chan WatchEvent, func()) {
+	sub := &watchSubscriber{
+		events: make(chan WatchEvent, 1),
+		gone:   make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		close(sub.events)
+		return sub.events, func() {}
+	}
+	w.subscribers[sub] = struct{}{}
+
+	return sub.events, func() {
+		sub.goneOnce.Do(func() { close(sub.gone) })
+		w.mu.Lock()
+		delete(w.subscribers, sub)
+		w.mu.Unlock()
+	}
+}
+
+// Start resolves the tag to record its current digest and then polls it in
+// the background until ctx is done or Close is called. The initial
+// resolution must succeed.
+func (w *Watcher) Start(ctx context.Context) error {
+	started := false
+	var err error
+	w.startOnce.Do(func() {
+		started = true
+		w.digest, w.spec, err = w.current(ctx)
+		if err != nil {
+			w.shutdown()
+			return
+		}
+		go w.run(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	if !started {
+		return fmt.Errorf("watcher already started or closed")
+	}
+	return nil
+}
+
+// Close stops polling, waits for an in-progress poll and event delivery to
+// finish, and closes every subscriber channel.
+func (w *Watcher) Close() error {
+	w.stopOnce.Do(func() { close(w.stop) })
+	w.startOnce.Do(w.shutdown)
+	<-w.done
+	return nil
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer w.shutdown()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if event, ok := w.poll(ctx); ok {
+			w.broadcast(ctx, event)
+		}
+	}
+}
+
+// poll resolves the tag and returns an event if it moved or polling failed.
+func (w *Watcher) poll(ctx context.Context) (WatchEvent, bool) {
+	desc, err := w.client.Resolve(ctx, w.reference)
+	if err != nil {
+		if ctx.Err() != nil {
+			return WatchEvent{}, false
+		}
+		return WatchEvent{Reference: w.reference, Err: err}, true
+	}
+
+	if desc.Digest.String() == w.digest {
+		return WatchEvent{}, false
+	}
+
+	newSpec, err := w.fetchSpec(ctx, desc.Digest.String())
+	if err != nil {
+		if ctx.Err() != nil {
+			return WatchEvent{}, false
+		}
+		return WatchEvent{Reference: w.reference, Err: err}, true
+	}
+
+	w.client.logger().InfoContext(ctx, "watched tag moved", "reference", w.reference,
+		"oldDigest", w.digest, "newDigest", desc.Digest)
+
+	event := WatchEvent{
+		Reference: w.reference,
+		OldDigest: w.digest,
+		NewDigest: desc.Digest.String(),
+		OldSpec:   w.spec,
+		NewSpec:   newSpec,
+		Changes:   spec.Diff(w.spec, newSpec),
+	}
+	w.digest, w.spec = event.NewDigest, newSpec
+
+	return event, true
+}
+
+func (w *Watcher) current(ctx context.Context) (string, *common.RuntimeSpec, error) {
+	desc, err := w.client.Resolve(ctx, w.reference)
+	if err != nil {
+		return "", nil, err
+	}
+
+	runtimeSpec, err := w.fetchSpec(ctx, desc.Digest.String())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return desc.Digest.String(), runtimeSpec, nil
+}
+
+// fetchSpec fetches the spec of the artifact with the given digest in the
+// watched repository, so that a tag moving again cannot race the fetch.
+func (w *Watcher) fetchSpec(ctx context.Context, d string) (*common.RuntimeSpec, error) {
+	ref, err := registry.ParseReference(w.reference)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference: %w", err)
+	}
+	ref.Reference = d
+
+	return w.client.fetchRuntimeSpec(ctx, ref.String())
+}
+
+func (w *Watcher) broadcast(ctx context.Context, event WatchEvent) {
+	w.mu.Lock()
+	subs := make([]*watchSubscriber, 0, len(w.subscribers))
+	for sub := range w.subscribers {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.events <- event:
+		case <-sub.gone:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// shutdown closes every subscriber channel once no more events can be sent.
+func (w *Watcher) shutdown() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+	w.closed = true
+
+	for sub := range w.subscribers {
+		close(sub.events)
+		delete(w.subscribers, sub)
+	}
+	close(w.done)
+}
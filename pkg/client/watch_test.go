@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+)
+
+func TestWatcher(t *testing.T) {
+	reg := registrytest.New(t)
+	reference := reg.PutRuntime(t, "example/runtime", "stable")
+
+	c := NewClient(ClientOptions{PlainHTTP: true})
+	w := c.Watch(reference, WatchOptions{Interval: 10 * time.Millisecond})
+
+	first, _ := w.Subscribe()
+	second, _ := w.Subscribe()
+	cancelled, cancel := w.Subscribe()
+	cancel()
+
+	if err := w.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start watcher: %v", err)
+	}
+	if err := w.Start(context.Background()); err == nil {
+		t.Error("Expected a second Start to fail")
+	}
+
+	oldDesc, err := c.Resolve(context.Background(), reference)
+	if err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+
+	updated := strings.Replace(registrytest.SpecYAML, "version: v1.0.0", "version: v1.1.0", 1)
+	image := reg.PutImage(t, "example/performer", []byte("performer layer"))
+	newDesc := reg.PutArtifact(t, "example/runtime", "stable", []byte(fmt.Sprintf(updated, reg.Host(), image.Digest)))
+
+	for _, events := range []<-chan WatchEvent{first, second} {
+		select {
+		case event := <-events:
+			if event.Err != nil {
+				t.Fatalf("Unexpected watch error: %v", event.Err)
+			}
+			if event.OldDigest != oldDesc.Digest.String() || event.NewDigest != newDesc.Digest.String() {
+				t.Errorf("Expected %s -> %s, got %s -> %s", oldDesc.Digest, newDesc.Digest, event.OldDigest, event.NewDigest)
+			}
+			if event.NewSpec == nil || event.NewSpec.Version != "v1.1.0" {
+				t.Errorf("Expected new spec version v1.1.0, got %+v", event.NewSpec)
+			}
+			expected := []spec.Change{{Path: "version", Type: spec.ChangeModified, Old: "v1.0.0", New: "v1.1.0"}}
+			if len(event.Changes) != 1 || event.Changes[0] != expected[0] {
+				t.Errorf("Expected changes %v, got %v", expected, event.Changes)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for watch event")
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close watcher: %v", err)
+	}
+
+	for _, events := range []<-chan WatchEvent{first, second} {
+		for range events {
+		}
+	}
+
+	select {
+	case event := <-cancelled:
+		t.Errorf("Expected no events after cancelling the subscription, got %+v", event)
+	default:
+	}
+}
+
+func TestWatcherStartFails(t *testing.T) {
+	reg := registrytest.New(t)
+
+	c := NewClient(ClientOptions{PlainHTTP: true, Retry: &RetryPolicy{MaxAttempts: 1}})
+	w := c.Watch(reg.Host()+"/example/missing:stable", WatchOptions{})
+	events, _ := w.Subscribe()
+
+	if err := w.Start(context.Background()); err == nil {
+		t.Fatal("Expected Start to fail for a missing tag")
+	}
+	if _, ok := <-events; ok {
+		t.Error("Expected the subscriber channel to be closed")
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Failed to close watcher: %v", err)
+	}
+}
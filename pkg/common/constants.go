@@ -3,13 +3,21 @@ package common
 const (
 	MediaTypeEigenRuntimeManifest = "application/vnd.eigenruntime.manifest.v1"
 	MediaTypeEigenRuntimeConfig   = "application/vnd.eigenruntime.manifest.config.v1+json"
-	MediaTypeYAML                  = "text/yaml"
+	MediaTypeYAML                 = "text/yaml"
 	MediaTypeOCIManifest          = "application/vnd.oci.image.manifest.v1+json"
 
 	AnnotationSpecVersion      = "io.eigenruntime.spec.version"
 	AnnotationImageCreated     = "org.opencontainers.image.created"
 	AnnotationImageDescription = "org.opencontainers.image.description"
 	AnnotationImageSource      = "org.opencontainers.image.source"
+	AnnotationImageVersion     = "org.opencontainers.image.version"
 
 	DefaultSpecVersion = "v1"
-)
\ No newline at end of file
+
+	DefaultAPIVersion = "eigenruntime.io/v1alpha1"
+	DefaultKind       = "Runtime"
+)
+
+// SupportedSpecVersions lists the spec format versions this library can
+// build and read.
+var SupportedSpecVersions = []string{DefaultSpecVersion}
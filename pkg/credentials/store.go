@@ -0,0 +1,384 @@
+// Package credentials stores registry credentials in the Docker config file
+// or with Docker credential helpers, so that credentials saved by docker
+// login, eigenruntime login or client.Client.Login are shared.
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// Store saves registry credentials keyed by host[:port].
+type Store interface {
+	// Get returns the credential for host, or auth.EmptyCredential if none
+	// is stored.
+	Get(ctx context.Context, host string) (auth.Credential, error)
+	// Put saves the credential for host, replacing any previous one.
+	Put(ctx context.Context, host string, cred auth.Credential) error
+	// Delete removes the credential for host. Deleting a credential that is
+	// not stored is not an error.
+	Delete(ctx context.Context, host string) error
+}
+
+// dockerHubServer is the key Docker uses for Docker Hub credentials.
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// tokenUsername is the username Docker stores with identity tokens.
+const tokenUsername = "<token>"
+
+// serverAddress returns the key under which Docker stores credentials for
+// host.
+func serverAddress(host string) string {
+	switch host {
+	case "docker.io", "index.docker.io", "registry-1.docker.io":
+		return dockerHubServer
+	}
+	return host
+}
+
+// DefaultConfigPath returns the Docker config file: config.json in
+// $DOCKER_CONFIG, or in ~/.docker when DOCKER_CONFIG is unset.
+func DefaultConfigPath() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return filepath.Join(dir, "config.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate Docker config: %w", err)
+	}
+	return filepath.Join(home, ".docker", "config.json"), nil
+}
+
+// Default returns the store backed by the file at DefaultConfigPath, or nil
+// if the home directory cannot be determined.
+func Default() Store {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return nil
+	}
+	return NewFileStore(path)
+}
+
+// Func adapts s to the Credential field of an oras auth.Client. A nil store
+// supplies no credentials.
+func Func(s Store) func(ctx context.Context, host string) (auth.Credential, error) {
+	return func(ctx context.Context, host string) (auth.Credential, error) {
+		if s == nil {
+			return auth.EmptyCredential, nil
+		}
+		return s.Get(ctx, host)
+	}
+}
+
+// FileStore keeps credentials in a Docker config file, so credentials saved
+// by docker login are used and vice versa. When the file names a credential
+// helper for a registry (credHelpers) or for all registries (credsStore),
+// credentials are kept by that helper instead of in the file.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a store backed by the Docker config file at path.
+// The file is created on the first Put if it does not exist.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// dockerConfig is a Docker config file. Fields this package does not use
+// are kept as they are when the file is written back.
+type dockerConfig struct {
+	raw         map[string]json.RawMessage
+	auths       map[string]json.RawMessage
+	credHelpers map[string]string
+	credsStore  string
+}
+
+type dockerAuth struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+func (s *FileStore) Get(ctx context.Context, host string) (auth.Credential, error) {
+	cfg, err := s.load()
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	if helper := cfg.helper(host); helper != "" {
+		return NewNativeStore(helper).Get(ctx, host)
+	}
+
+	raw, ok := cfg.lookup(host)
+	if !ok {
+		return auth.EmptyCredential, nil
+	}
+	var entry dockerAuth
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return auth.EmptyCredential, fmt.Errorf("invalid credentials for %s in %s: %w", host, s.path, err)
+	}
+
+	cred := auth.Credential{
+		Username:     entry.Username,
+		Password:     entry.Password,
+		RefreshToken: entry.IdentityToken,
+		AccessToken:  entry.RegistryToken,
+	}
+	if entry.Auth != "" {
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return auth.EmptyCredential, fmt.Errorf("invalid credentials for %s in %s: %w", host, s.path, err)
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return auth.EmptyCredential, fmt.Errorf("invalid credentials for %s in %s: expected username:password", host, s.path)
+		}
+		cred.Username, cred.Password = username, password
+	}
+	if cred.RefreshToken != "" && cred.Username == tokenUsername {
+		cred.Username = ""
+	}
+	return cred, nil
+}
+
+func (s *FileStore) Put(ctx context.Context, host string, cred auth.Credential) error {
+	cfg, err := s.load()
+	if err != nil {
+		return err
+	}
+	if helper := cfg.helper(host); helper != "" {
+		return NewNativeStore(helper).Put(ctx, host, cred)
+	}
+
+	entry := dockerAuth{IdentityToken: cred.RefreshToken, RegistryToken: cred.AccessToken}
+	switch {
+	case cred.Username != "" || cred.Password != "":
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(cred.Username + ":" + cred.Password))
+	case cred.RefreshToken != "":
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(tokenUsername + ":"))
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if key, ok := cfg.key(host); ok {
+		delete(cfg.auths, key)
+	}
+	cfg.auths[serverAddress(host)] = data
+	return s.save(cfg)
+}
+
+func (s *FileStore) Delete(ctx context.Context, host string) error {
+	cfg, err := s.load()
+	if err != nil {
+		return err
+	}
+	if helper := cfg.helper(host); helper != "" {
+		return NewNativeStore(helper).Delete(ctx, host)
+	}
+
+	key, ok := cfg.key(host)
+	if !ok {
+		return nil
+	}
+	delete(cfg.auths, key)
+	return s.save(cfg)
+}
+
+func (s *FileStore) load() (*dockerConfig, error) {
+	cfg := &dockerConfig{
+		raw:   make(map[string]json.RawMessage),
+		auths: make(map[string]json.RawMessage),
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Docker config: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return cfg, nil
+	}
+
+	if err := json.Unmarshal(data, &cfg.raw); err != nil {
+		return nil, fmt.Errorf("failed to parse Docker config %s: %w", s.path, err)
+	}
+	for key, v := range map[string]any{"auths": &cfg.auths, "credHelpers": &cfg.credHelpers, "credsStore": &cfg.credsStore} {
+		if raw, ok := cfg.raw[key]; ok {
+			if err := json.Unmarshal(raw, v); err != nil {
+				return nil, fmt.Errorf("failed to parse %s in Docker config %s: %w", key, s.path, err)
+			}
+		}
+	}
+	if cfg.auths == nil {
+		cfg.auths = make(map[string]json.RawMessage)
+	}
+	return cfg, nil
+}
+
+// save writes cfg to a temporary file and renames it over the config file,
+// so a failed write never leaves a truncated config behind.
+func (s *FileStore) save(cfg *dockerConfig) error {
+	auths, err := json.Marshal(cfg.auths)
+	if err != nil {
+		return err
+	}
+	cfg.raw["auths"] = auths
+
+	data, err := json.MarshalIndent(cfg.raw, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create Docker config directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write Docker config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write Docker config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write Docker config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write Docker config: %w", err)
+	}
+	return nil
+}
+
+// helper returns the credential helper configured for host, if any.
+func (cfg *dockerConfig) helper(host string) string {
+	if helper, ok := cfg.credHelpers[serverAddress(host)]; ok {
+		return helper
+	}
+	if helper, ok := cfg.credHelpers[host]; ok {
+		return helper
+	}
+	return cfg.credsStore
+}
+
+// key returns the auths key holding credentials for host. Besides host
+// itself, Docker accepts keys written as URLs, such as "https://ghcr.io".
+func (cfg *dockerConfig) key(host string) (string, bool) {
+	address := serverAddress(host)
+	if _, ok := cfg.auths[address]; ok {
+		return address, true
+	}
+	for key := range cfg.auths {
+		trimmed := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+		trimmed, _, _ = strings.Cut(trimmed, "/")
+		if trimmed == host {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+func (cfg *dockerConfig) lookup(host string) (json.RawMessage, bool) {
+	key, ok := cfg.key(host)
+	if !ok {
+		return nil, false
+	}
+	return cfg.auths[key], true
+}
+
+// NativeStore keeps credentials with a Docker credential helper, an
+// executable named docker-credential-<helper> on PATH such as
+// docker-credential-osxkeychain or docker-credential-pass.
+type NativeStore struct {
+	program string
+}
+
+// NewNativeStore returns a store backed by docker-credential-<helper>.
+func NewNativeStore(helper string) *NativeStore {
+	return &NativeStore{program: "docker-credential-" + helper}
+}
+
+// helperCredential is the payload exchanged with credential helpers.
+type helperCredential struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// errHelperNotFound is the message helpers print when they hold no
+// credentials for a server.
+const errHelperNotFound = "credentials not found in native keychain"
+
+func (s *NativeStore) Get(ctx context.Context, host string) (auth.Credential, error) {
+	out, err := s.run(ctx, "get", serverAddress(host))
+	if err != nil {
+		if strings.Contains(err.Error(), errHelperNotFound) {
+			return auth.EmptyCredential, nil
+		}
+		return auth.EmptyCredential, err
+	}
+
+	var hc helperCredential
+	if err := json.Unmarshal(out, &hc); err != nil {
+		return auth.EmptyCredential, fmt.Errorf("invalid output from %s: %w", s.program, err)
+	}
+	if hc.Username == tokenUsername {
+		return auth.Credential{RefreshToken: hc.Secret}, nil
+	}
+	return auth.Credential{Username: hc.Username, Password: hc.Secret}, nil
+}
+
+func (s *NativeStore) Put(ctx context.Context, host string, cred auth.Credential) error {
+	hc := helperCredential{ServerURL: serverAddress(host), Username: cred.Username, Secret: cred.Password}
+	if cred.RefreshToken != "" {
+		hc.Username, hc.Secret = tokenUsername, cred.RefreshToken
+	}
+	data, err := json.Marshal(hc)
+	if err != nil {
+		return err
+	}
+	_, err = s.run(ctx, "store", string(data))
+	return err
+}
+
+func (s *NativeStore) Delete(ctx context.Context, host string) error {
+	_, err := s.run(ctx, "erase", serverAddress(host))
+	if err != nil && strings.Contains(err.Error(), errHelperNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *NativeStore) run(ctx context.Context, action, input string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.program, action)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Helpers report errors on stdout.
+		msg := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s: %s", s.program, action, msg)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,218 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "docker", "config.json")
+	store := NewFileStore(path)
+
+	cred, err := store.Get(ctx, "ghcr.io")
+	if err != nil {
+		t.Fatalf("Failed to read missing config: %v", err)
+	}
+	if cred != auth.EmptyCredential {
+		t.Errorf("Expected no credentials, got %+v", cred)
+	}
+
+	tests := []struct {
+		name string
+		host string
+		cred auth.Credential
+		key  string
+	}{
+		{name: "basic", host: "ghcr.io", cred: auth.Credential{Username: "user", Password: "pass:word"}, key: "ghcr.io"},
+		{name: "identity token", host: "registry.example.com:5000", cred: auth.Credential{RefreshToken: "refresh"}, key: "registry.example.com:5000"},
+		{name: "docker hub", host: "registry-1.docker.io", cred: auth.Credential{Username: "hub", Password: "secret"}, key: dockerHubServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.Put(ctx, tt.host, tt.cred); err != nil {
+				t.Fatalf("Failed to store credentials: %v", err)
+			}
+			got, err := store.Get(ctx, tt.host)
+			if err != nil {
+				t.Fatalf("Failed to read credentials: %v", err)
+			}
+			if got != tt.cred {
+				t.Errorf("Expected %+v, got %+v", tt.cred, got)
+			}
+
+			cfg, err := store.load()
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if _, ok := cfg.auths[tt.key]; !ok {
+				t.Errorf("Expected credentials under %q, got keys %v", tt.key, cfg.auths)
+			}
+		})
+	}
+
+	if err := store.Delete(ctx, "ghcr.io"); err != nil {
+		t.Fatalf("Failed to delete credentials: %v", err)
+	}
+	if cred, _ := store.Get(ctx, "ghcr.io"); cred != auth.EmptyCredential {
+		t.Errorf("Expected credentials to be deleted, got %+v", cred)
+	}
+	if err := store.Delete(ctx, "ghcr.io"); err != nil {
+		t.Errorf("Expected deleting missing credentials to succeed, got %v", err)
+	}
+}
+
+func TestFileStoreExistingConfig(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := `{
+	"auths": {
+		"https://ghcr.io": {"auth": "dXNlcjpwYXNz", "email": "user@example.com"}
+	},
+	"psFormat": "table {{.ID}}"
+}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store := NewFileStore(path)
+
+	cred, err := store.Get(ctx, "ghcr.io")
+	if err != nil {
+		t.Fatalf("Failed to read credentials: %v", err)
+	}
+	if cred.Username != "user" || cred.Password != "pass" {
+		t.Errorf("Expected user/pass, got %+v", cred)
+	}
+
+	if err := store.Put(ctx, "quay.io", auth.Credential{Username: "other", Password: "secret"}); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	for _, want := range []string{`"psFormat"`, `"email"`, `"https://ghcr.io"`, `"quay.io"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected config to keep %s, got:\n%s", want, data)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat config: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected config mode 0600, got %v", info.Mode().Perm())
+	}
+}
+
+// installCredentialHelper puts a docker-credential-test helper on PATH that
+// keeps credentials as files in a temporary directory.
+func installCredentialHelper(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	script := `#!/bin/sh
+store="` + dir + `/store"
+mkdir -p "$store"
+case "$1" in
+get)
+	read -r server
+	file="$store/$(echo "$server" | tr '/:' '__')"
+	[ -f "$file" ] || { echo "credentials not found in native keychain"; exit 1; }
+	cat "$file"
+	;;
+store)
+	read -r payload
+	server=$(echo "$payload" | sed 's/.*"ServerURL":"\([^"]*\)".*/\1/')
+	echo "$payload" > "$store/$(echo "$server" | tr '/:' '__')"
+	;;
+erase)
+	read -r server
+	file="$store/$(echo "$server" | tr '/:' '__')"
+	[ -f "$file" ] || { echo "credentials not found in native keychain"; exit 1; }
+	rm "$file"
+	;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(dir, "docker-credential-test"), []byte(script), 0o755); err != nil {
+		t.Fatalf("Failed to write credential helper: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+func TestNativeStore(t *testing.T) {
+	installCredentialHelper(t)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"credsStore": "test"}`), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	store := NewFileStore(path)
+
+	for _, cred := range []auth.Credential{
+		{Username: "user", Password: "pass"},
+		{RefreshToken: "refresh"},
+	} {
+		if err := store.Put(ctx, "ghcr.io", cred); err != nil {
+			t.Fatalf("Failed to store credentials: %v", err)
+		}
+		got, err := store.Get(ctx, "ghcr.io")
+		if err != nil {
+			t.Fatalf("Failed to read credentials: %v", err)
+		}
+		if got != cred {
+			t.Errorf("Expected %+v, got %+v", cred, got)
+		}
+	}
+
+	var cfg map[string]json.RawMessage
+	data, _ := os.ReadFile(path)
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if _, ok := cfg["auths"]; ok {
+		t.Errorf("Expected credentials to stay out of the config file, got:\n%s", data)
+	}
+
+	if err := store.Delete(ctx, "ghcr.io"); err != nil {
+		t.Fatalf("Failed to delete credentials: %v", err)
+	}
+	if cred, err := store.Get(ctx, "ghcr.io"); err != nil || cred != auth.EmptyCredential {
+		t.Errorf("Expected no credentials after delete, got %+v, %v", cred, err)
+	}
+	if err := store.Delete(ctx, "ghcr.io"); err != nil {
+		t.Errorf("Expected deleting missing credentials to succeed, got %v", err)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+
+	store := Default()
+	if err := store.Put(ctx, "ghcr.io", auth.Credential{Username: "user", Password: "pass"}); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err != nil {
+		t.Errorf("Expected credentials in $DOCKER_CONFIG/config.json: %v", err)
+	}
+
+	cred, err := Func(store)(ctx, "ghcr.io")
+	if err != nil || cred.Username != "user" {
+		t.Errorf("Expected stored credentials, got %+v, %v", cred, err)
+	}
+	if cred, err := Func(nil)(ctx, "ghcr.io"); err != nil || cred != auth.EmptyCredential {
+		t.Errorf("Expected no credentials from a nil store, got %+v, %v", cred, err)
+	}
+}
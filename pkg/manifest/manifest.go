@@ -7,32 +7,56 @@ import (
 
 	"github.com/Layr-Labs/eigenruntime-go/pkg/artifact"
 	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
+// Aliases of the common media types and annotations used in manifests.
+const (
+	MediaTypeEigenRuntimeManifest = common.MediaTypeEigenRuntimeManifest
+	MediaTypeEigenRuntimeConfig   = common.MediaTypeEigenRuntimeConfig
+	MediaTypeYAML                 = common.MediaTypeYAML
+	MediaTypeOCIManifest          = common.MediaTypeOCIManifest
+
+	AnnotationSpecVersion      = common.AnnotationSpecVersion
+	AnnotationImageCreated     = common.AnnotationImageCreated
+	AnnotationImageDescription = common.AnnotationImageDescription
+	AnnotationImageSource      = common.AnnotationImageSource
+	AnnotationImageVersion     = common.AnnotationImageVersion
+)
+
 type BuildOptions struct {
 	Description string
 	Source      string
-	Version     string
-	Annotations map[string]string
-	CreatedTime *time.Time
+	// Version is the format version of the spec, recorded as the
+	// io.eigenruntime.spec.version annotation. It defaults to
+	// common.DefaultSpecVersion and must be one of
+	// common.SupportedSpecVersions.
+	Version string
+	// RuntimeVersion is the semantic version of the runtime, recorded
+	// normalized as the org.opencontainers.image.version annotation. It
+	// defaults to the version field of the spec and must match it if both
+	// are set.
+	RuntimeVersion string
+	Annotations    map[string]string
+	CreatedTime    *time.Time
 }
 
 type Manifest struct {
-	SchemaVersion int                    `json:"schemaVersion"`
-	MediaType     string                 `json:"mediaType"`
-	ArtifactType  string                 `json:"artifactType"`
-	Config        ocispec.Descriptor     `json:"config"`
-	Layers        []ocispec.Descriptor   `json:"layers"`
-	Annotations   map[string]string      `json:"annotations,omitempty"`
+	SchemaVersion int                  `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	ArtifactType  string               `json:"artifactType"`
+	Config        ocispec.Descriptor   `json:"config"`
+	Layers        []ocispec.Descriptor `json:"layers"`
+	Annotations   map[string]string    `json:"annotations,omitempty"`
 }
 
 func CreateMinimalConfig() []byte {
 	config := map[string]interface{}{
 		"created": time.Now().Format(time.RFC3339),
 	}
-	
+
 	data, _ := json.Marshal(config)
 	return data
 }
@@ -41,31 +65,42 @@ func CreateManifest(specContent []byte, config []byte, opts BuildOptions) (*Mani
 	if opts.Annotations == nil {
 		opts.Annotations = make(map[string]string)
 	}
-	
+
 	createdTime := time.Now()
 	if opts.CreatedTime != nil {
 		createdTime = *opts.CreatedTime
 	}
-	
-	version := common.DefaultSpecVersion
+
+	specVersion := common.DefaultSpecVersion
 	if opts.Version != "" {
-		version = opts.Version
+		specVersion = opts.Version
+	}
+	if err := spec.ValidateSpecVersion(specVersion); err != nil {
+		return nil, err
+	}
+
+	runtimeVersion, err := spec.ResolveVersion(specContent, opts.RuntimeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Annotations[common.AnnotationSpecVersion] = specVersion
+	if runtimeVersion != "" {
+		opts.Annotations[common.AnnotationImageVersion] = runtimeVersion
 	}
-	
-	opts.Annotations[common.AnnotationSpecVersion] = version
 	opts.Annotations[common.AnnotationImageCreated] = createdTime.Format(time.RFC3339)
-	
+
 	if opts.Description != "" {
 		opts.Annotations[common.AnnotationImageDescription] = opts.Description
 	}
-	
+
 	if opts.Source != "" {
 		opts.Annotations[common.AnnotationImageSource] = opts.Source
 	}
 
 	specDigest := artifact.ComputeDigest(specContent)
 	configDigest := artifact.ComputeDigest(config)
-	
+
 	manifest := &Manifest{
 		SchemaVersion: 2,
 		MediaType:     common.MediaTypeOCIManifest,
@@ -84,7 +119,7 @@ func CreateManifest(specContent []byte, config []byte, opts BuildOptions) (*Mani
 		},
 		Annotations: opts.Annotations,
 	}
-	
+
 	return manifest, nil
 }
 
@@ -98,4 +133,4 @@ func ParseManifest(data []byte) (*Manifest, error) {
 		return nil, fmt.Errorf("failed to parse manifest: %w", err)
 	}
 	return &manifest, nil
-}
\ No newline at end of file
+}
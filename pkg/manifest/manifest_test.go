@@ -143,4 +143,28 @@ func TestCreateManifestWithCustomTime(t *testing.T) {
 	if manifest.Annotations[AnnotationImageCreated] != expectedTime {
 		t.Errorf("Expected created time %s, got %s", expectedTime, manifest.Annotations[AnnotationImageCreated])
 	}
-}
\ No newline at end of file
+}
+
+func TestCreateManifestVersions(t *testing.T) {
+	specContent := []byte("apiVersion: eigenruntime.io/v1alpha1\nkind: Runtime\nname: test\nversion: v1.2\n")
+
+	manifest, err := CreateManifest(specContent, []byte("config"), BuildOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create manifest: %v", err)
+	}
+
+	if manifest.Annotations[AnnotationImageVersion] != "1.2.0" {
+		t.Errorf("Expected image version 1.2.0, got %s", manifest.Annotations[AnnotationImageVersion])
+	}
+	if manifest.Annotations[AnnotationSpecVersion] != "v1" {
+		t.Errorf("Expected spec version v1, got %s", manifest.Annotations[AnnotationSpecVersion])
+	}
+
+	if _, err := CreateManifest(specContent, []byte("config"), BuildOptions{RuntimeVersion: "2.0.0"}); err == nil {
+		t.Error("Expected a version conflicting with the spec to be rejected")
+	}
+
+	if _, err := CreateManifest(specContent, []byte("config"), BuildOptions{Version: "v2"}); err == nil {
+		t.Error("Expected an unsupported spec version to be rejected")
+	}
+}
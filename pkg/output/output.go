@@ -0,0 +1,157 @@
+// Package output renders command results for people and scripts.
+//
+// Every format is derived from a result's JSON encoding, so the field names
+// used by json, yaml and template output are the same stable schema.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formatter writes a result to w.
+type Formatter interface {
+	Format(w io.Writer, v any) error
+}
+
+// Tabular is implemented by results that can be printed as a table.
+type Tabular interface {
+	Table() (header []string, rows [][]string)
+}
+
+// Parse returns the formatter for format: "json", "yaml", "table", or
+// "template=<go-template>".
+func Parse(format string) (Formatter, error) {
+	switch {
+	case format == "json":
+		return JSON{}, nil
+	case format == "yaml":
+		return YAML{}, nil
+	case format == "table":
+		return Table{}, nil
+	case strings.HasPrefix(format, "template="):
+		return NewTemplate(strings.TrimPrefix(format, "template="))
+	}
+	return nil, fmt.Errorf("unsupported output format %q: expected json, yaml, table or template=<go-template>", format)
+}
+
+// JSON formats results as indented JSON.
+type JSON struct{}
+
+func (JSON) Format(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// YAML formats results as YAML with the field names and order of their
+// JSON encoding.
+type YAML struct{}
+
+func (YAML) Format(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// JSON is valid YAML; decoding it into a node keeps the field order,
+	// and clearing the flow styles makes it encode as block YAML.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	clearStyle(&node)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+// Table formats Tabular results as aligned columns.
+type Table struct{}
+
+func (Table) Format(w io.Writer, v any) error {
+	t, ok := v.(Tabular)
+	if !ok {
+		return fmt.Errorf("table output is not supported for %T", v)
+	}
+
+	header, rows := t.Table()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// Template formats results with a Go template. The template is executed
+// against the result's JSON encoding, so fields are referred to by their
+// JSON names, e.g. {{.digest}}.
+type Template struct {
+	tmpl *template.Template
+}
+
+// NewTemplate parses text as a Go template. The json and yaml functions
+// render a value in those formats.
+func NewTemplate(text string) (*Template, error) {
+	tmpl, err := template.New("output").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"yaml": func(v any) (string, error) {
+			var buf bytes.Buffer
+			err := YAML{}.Format(&buf, v)
+			return buf.String(), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+func (t *Template) Format(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// Decode numbers as json.Number so integers print as written rather
+	// than as float64, e.g. 12345678 instead of 1.2345678e+07.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return err
+	}
+	return t.tmpl.Execute(w, generic)
+}
+
+// Text is the human-readable default: Tabular results are printed as a
+// table and anything else as YAML.
+type Text struct{}
+
+func (Text) Format(w io.Writer, v any) error {
+	if _, ok := v.(Tabular); ok {
+		return Table{}.Format(w, v)
+	}
+	return YAML{}.Format(w, v)
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type result struct {
+	Name   string            `json:"name"`
+	Digest string            `json:"digest"`
+	Tags   []string          `json:"tags,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type sizedResult struct {
+	Size int64 `json:"size"`
+}
+
+type tabularResult struct {
+	result
+}
+
+func (r tabularResult) Table() ([]string, [][]string) {
+	return []string{"NAME", "DIGEST"}, [][]string{{r.Name, r.Digest}}
+}
+
+func TestFormats(t *testing.T) {
+	v := result{Name: "runtime", Digest: "sha256:abc", Tags: []string{"v1", "stable"}, Labels: map[string]string{"team": "avs"}}
+
+	tests := []struct {
+		format   string
+		value    any
+		expected string
+		wantErr  string
+	}{
+		{
+			format:   "json",
+			value:    v,
+			expected: "{\n  \"name\": \"runtime\",\n  \"digest\": \"sha256:abc\",\n  \"tags\": [\n    \"v1\",\n    \"stable\"\n  ],\n  \"labels\": {\n    \"team\": \"avs\"\n  }\n}\n",
+		},
+		{
+			format:   "yaml",
+			value:    v,
+			expected: "name: runtime\ndigest: sha256:abc\ntags:\n  - v1\n  - stable\nlabels:\n  team: avs\n",
+		},
+		{
+			format:   "table",
+			value:    tabularResult{v},
+			expected: "NAME     DIGEST\nruntime  sha256:abc\n",
+		},
+		{
+			format:  "table",
+			value:   v,
+			wantErr: "table output is not supported",
+		},
+		{
+			format:   "template={{.name}}@{{.digest}} {{range .tags}}[{{.}}]{{end}} {{json .labels}}",
+			value:    v,
+			expected: `runtime@sha256:abc [v1][stable] {"team":"avs"}`,
+		},
+		{
+			format:   "template={{.size}} {{json .}} {{yaml .}}",
+			value:    sizedResult{Size: 12345678},
+			expected: "12345678 {\"size\":12345678} size: 12345678\n",
+		},
+		{
+			format:  "template={{index .tags 5}}",
+			value:   v,
+			wantErr: "index out of range",
+		},
+		{
+			format:  "template={{.name",
+			wantErr: "invalid output template",
+		},
+		{
+			format:  "xml",
+			wantErr: "unsupported output format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			f, err := Parse(tt.format)
+			if err == nil {
+				var buf bytes.Buffer
+				err = f.Format(&buf, tt.value)
+				if err == nil && buf.String() != tt.expected {
+					t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, buf.String())
+				}
+			}
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Failed to format: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (Text{}).Format(&buf, tabularResult{result{Name: "a", Digest: "b"}}); err != nil {
+		t.Fatalf("Failed to format: %v", err)
+	}
+	if buf.String() != "NAME  DIGEST\na     b\n" {
+		t.Errorf("Expected a table, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := (Text{}).Format(&buf, result{Name: "a", Digest: "b"}); err != nil {
+		t.Fatalf("Failed to format: %v", err)
+	}
+	if buf.String() != "name: a\ndigest: b\n" {
+		t.Errorf("Expected YAML, got:\n%s", buf.String())
+	}
+}
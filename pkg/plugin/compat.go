@@ -0,0 +1,139 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Masterminds/semver/v3"
+)
+
+// APIVersion is the version of the plugin API implemented by this host.
+// Plugins declare the range of API versions they support in
+// Capabilities.APIVersions.
+const APIVersion = "1.0.0"
+
+var (
+	// ErrIncompatiblePlugin is returned when a plugin does not support the
+	// host's API version.
+	ErrIncompatiblePlugin = errors.New("incompatible plugin")
+
+	// ErrKindClaimed is returned when two plugins claim the same spec kind.
+	ErrKindClaimed = errors.New("spec kind already claimed")
+
+	// ErrNoPlugin is returned when no plugin claims a spec's kind.
+	ErrNoPlugin = errors.New("no plugin for spec kind")
+)
+
+// SpecType identifies runtime specs by apiVersion and kind. An empty
+// APIVersion matches every apiVersion of the kind.
+type SpecType struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+}
+
+func (t SpecType) String() string {
+	if t.APIVersion == "" {
+		return t.Kind
+	}
+	return t.APIVersion + "/" + t.Kind
+}
+
+// Matches reports whether spec is of this type.
+func (t SpecType) Matches(spec *common.RuntimeSpec) bool {
+	return t.Kind == spec.Kind && (t.APIVersion == "" || t.APIVersion == spec.APIVersion)
+}
+
+func (t SpecType) overlaps(other SpecType) bool {
+	return t.Kind == other.Kind && (t.APIVersion == "" || other.APIVersion == "" || t.APIVersion == other.APIVersion)
+}
+
+// Capabilities describes what a plugin supports.
+type Capabilities struct {
+	// APIVersions is a semver constraint on the host API version, e.g.
+	// "^1.0".
+	APIVersions string `json:"apiVersions,omitempty"`
+
+	// Specs lists the spec types the plugin deploys. Specs of these types
+	// are routed to the plugin.
+	Specs []SpecType `json:"specs,omitempty"`
+}
+
+// CapabilityDeclarer is implemented by plugins that declare their
+// capabilities. Plugins that do not are registered with a warning and are
+// never routed specs by kind.
+type CapabilityDeclarer interface {
+	Capabilities() Capabilities
+}
+
+func capabilities(p EigenRuntimePlugin) Capabilities {
+	if d, ok := p.(CapabilityDeclarer); ok {
+		return d.Capabilities()
+	}
+	return Capabilities{}
+}
+
+// checkCompatibility reports whether the host can load p. It returns
+// warnings for plugins that can be loaded but may misbehave.
+func checkCompatibility(p EigenRuntimePlugin, caps Capabilities) (warnings []string, err error) {
+	if _, err := semver.NewVersion(p.Version()); err != nil {
+		warnings = append(warnings, fmt.Sprintf("version %q is not a semantic version", p.Version()))
+	}
+
+	if caps.APIVersions == "" {
+		return append(warnings, fmt.Sprintf("plugin does not declare supported API versions; host API version is %s", APIVersion)), nil
+	}
+
+	constraint, err := semver.NewConstraint(caps.APIVersions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: invalid API version range %q: %w", ErrIncompatiblePlugin, p.Name(), caps.APIVersions, err)
+	}
+	if !constraint.Check(semver.MustParse(APIVersion)) {
+		return nil, fmt.Errorf("%w: %s supports API versions %s, host API version is %s", ErrIncompatiblePlugin, p.Name(), caps.APIVersions, APIVersion)
+	}
+
+	for _, t := range caps.Specs {
+		if t.Kind == "" {
+			return nil, fmt.Errorf("%w: %s declares a spec type without a kind", ErrIncompatiblePlugin, p.Name())
+		}
+	}
+
+	return warnings, nil
+}
+
+// PluginFor returns the plugin that claims the apiVersion and kind of spec.
+func (h *Host) PluginFor(spec *common.RuntimeSpec) (EigenRuntimePlugin, error) {
+	for _, p := range h.Plugins() {
+		for _, t := range h.caps[p.Name()].Specs {
+			if t.Matches(spec) {
+				return p, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoPlugin, SpecType{APIVersion: spec.APIVersion, Kind: spec.Kind})
+}
+
+func (h *Host) kindClaimant(t SpecType, pending map[string]Capabilities) (string, bool) {
+	for _, claims := range []map[string]Capabilities{h.caps, pending} {
+		for name, caps := range claims {
+			for _, claimed := range caps.Specs {
+				if claimed.overlaps(t) {
+					return name, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func formatSpecTypes(types []SpecType) string {
+	if len(types) == 0 {
+		return "-"
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ",")
+}
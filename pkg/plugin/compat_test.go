@@ -0,0 +1,144 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+// capablePlugin is a contextPlugin that declares its capabilities.
+type capablePlugin struct {
+	contextPlugin
+	name string
+	caps Capabilities
+}
+
+func (p *capablePlugin) Name() string               { return p.name }
+func (p *capablePlugin) Capabilities() Capabilities { return p.caps }
+
+func TestRegisterCompatibility(t *testing.T) {
+	runtimeType := []SpecType{{APIVersion: "eigenruntime.io/v1alpha1", Kind: "Runtime"}}
+
+	tests := []struct {
+		name    string
+		plugins []EigenRuntimePlugin
+		wantErr error
+		warning string
+	}{
+		{
+			name:    "compatible",
+			plugins: []EigenRuntimePlugin{&capablePlugin{name: "a", caps: Capabilities{APIVersions: "^1.0", Specs: runtimeType}}},
+		},
+		{
+			name:    "undeclared",
+			plugins: []EigenRuntimePlugin{&TestPlugin{}},
+			warning: "does not declare supported API versions",
+		},
+		{
+			name:    "unsupported API version",
+			plugins: []EigenRuntimePlugin{&capablePlugin{name: "a", caps: Capabilities{APIVersions: "^2.0"}}},
+			wantErr: ErrIncompatiblePlugin,
+		},
+		{
+			name:    "invalid API version range",
+			plugins: []EigenRuntimePlugin{&capablePlugin{name: "a", caps: Capabilities{APIVersions: "one"}}},
+			wantErr: ErrIncompatiblePlugin,
+		},
+		{
+			name: "same kind",
+			plugins: []EigenRuntimePlugin{
+				&capablePlugin{name: "a", caps: Capabilities{APIVersions: "^1.0", Specs: runtimeType}},
+				&capablePlugin{name: "b", caps: Capabilities{APIVersions: "^1.0", Specs: []SpecType{{Kind: "Runtime"}}}},
+			},
+			wantErr: ErrKindClaimed,
+		},
+		{
+			name: "same kind, different apiVersion",
+			plugins: []EigenRuntimePlugin{
+				&capablePlugin{name: "a", caps: Capabilities{APIVersions: "^1.0", Specs: runtimeType}},
+				&capablePlugin{name: "b", caps: Capabilities{APIVersions: "^1.0", Specs: []SpecType{{APIVersion: "eigenruntime.io/v1", Kind: "Runtime"}}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			h := NewHost(HostOptions{Logger: slog.New(slog.NewTextHandler(&logs, nil))})
+
+			err := h.Register(tt.plugins...)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+				}
+				if len(h.Plugins()) != 0 {
+					t.Error("Expected no plugins to be registered")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to register plugins: %v", err)
+			}
+
+			if tt.warning == "" && logs.Len() > 0 {
+				t.Errorf("Expected no warnings, got %s", logs.String())
+			}
+			if !strings.Contains(logs.String(), tt.warning) {
+				t.Errorf("Expected warning %q, got %s", tt.warning, logs.String())
+			}
+		})
+	}
+}
+
+func TestRouteByKind(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(contextTestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	var routed string
+	deployer := &capablePlugin{
+		name: "deployer",
+		caps: Capabilities{APIVersions: ">= 1.0.0", Specs: []SpecType{{Kind: "Runtime"}}},
+	}
+	deployer.action = func(ctx *Context) error {
+		routed = ctx.Spec.Name + " " + strings.Join(ctx.Args, " ")
+		return nil
+	}
+	other := &capablePlugin{name: "other", caps: Capabilities{APIVersions: "^1", Specs: []SpecType{{Kind: "Job"}}}}
+
+	h := NewHost(HostOptions{})
+	if err := h.Register(deployer, other); err != nil {
+		t.Fatalf("Failed to register plugins: %v", err)
+	}
+
+	p, err := h.PluginFor(&common.RuntimeSpec{APIVersion: "eigenruntime.io/v1alpha1", Kind: "Job"})
+	if err != nil || p.Name() != "other" {
+		t.Errorf("Expected Job to route to other, got %v, %v", p, err)
+	}
+	if _, err := h.PluginFor(&common.RuntimeSpec{Kind: "Unknown"}); !errors.Is(err, ErrNoPlugin) {
+		t.Errorf("Expected ErrNoPlugin, got %v", err)
+	}
+
+	app, out := newTestApp(t, h)
+	if err := app.Run([]string{"eigenruntime", "run", specPath, "extra"}); err != nil {
+		t.Fatalf("Failed to run routed command: %v", err)
+	}
+	if routed != "example-runtime extra" {
+		t.Errorf("Expected the deployer to run the spec, got %q", routed)
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"eigenruntime", "plugins", "list"}); err != nil {
+		t.Fatalf("Failed to list plugins: %v", err)
+	}
+	if !strings.Contains(out.String(), "deployer  1.0.0    Runtime") {
+		t.Errorf("Expected claimed kinds in plugin list, got:\n%s", out.String())
+	}
+}
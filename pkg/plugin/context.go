@@ -0,0 +1,234 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/output"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+	"oras.land/oras-go/v2/registry"
+)
+
+// ErrMissingReference is returned when a command built with Action is run
+// without an artifact reference.
+var ErrMissingReference = errors.New("missing artifact reference")
+
+// Context is passed to plugin commands built with Action. It carries the
+// runtime spec named by the command's first argument, resolved by the host,
+// so plugins implement deployment logic without pulling and parsing specs
+// themselves.
+type Context struct {
+	context.Context
+
+	// CLI is the underlying command context, for plugin-specific flags.
+	CLI *cli.Context
+
+	// Reference is the artifact reference or spec file the command was
+	// given, and Args the arguments that followed it.
+	Reference string
+	Args      []string
+
+	// Spec is the resolved runtime spec. Digest is the digest of the
+	// artifact it was pulled from, or empty for a local spec file.
+	Spec   *common.RuntimeSpec
+	Digest string
+
+	Client *client.Client
+	Logger *slog.Logger
+	Output output.Formatter
+	Stdout io.Writer
+
+	lookupEnv func(string) (string, bool)
+}
+
+// Print formats v with the configured output formatter.
+func (c *Context) Print(v any) error {
+	return c.Output.Format(c.Stdout, v)
+}
+
+// Env resolves the environment variables declared by a component of the
+// spec from the process environment. Unset optional variables are omitted;
+// an unset required variable is an error.
+func (c *Context) Env(component string) (map[string]string, error) {
+	comp, ok := c.Spec.Spec[component]
+	if !ok {
+		return nil, fmt.Errorf("component %q not found in spec %s", component, c.Spec.Name)
+	}
+
+	env := make(map[string]string, len(comp.Env))
+	var missing []string
+	for _, v := range comp.Env {
+		value, ok := c.lookupEnv(v.Name)
+		if !ok {
+			if v.Required {
+				missing = append(missing, v.Name)
+			}
+			continue
+		}
+		env[v.Name] = value
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("component %s: required environment variables not set: %v", component, missing)
+	}
+	return env, nil
+}
+
+// Action adapts fn to a command action. The command's first argument names
+// the runtime: a spec file on disk, or otherwise an artifact reference that
+// is pulled with the host's client.
+func Action(fn func(*Context) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		h, ok := c.Context.Value(hostKey{}).(*Host)
+		if !ok {
+			h = NewHost(HostOptions{})
+		}
+
+		ctx, err := h.newContext(c)
+		if err != nil {
+			return err
+		}
+		return fn(ctx)
+	}
+}
+
+type hostKey struct{}
+
+type policyKey struct{}
+
+// withHost makes h, and the admission policy given with --policy, available
+// to the actions of a command and its subcommands.
+func (h *Host) withHost(c *cli.Context) error {
+	c.Context = context.WithValue(c.Context, hostKey{}, h)
+	if path := c.String(policyFlag.Name); path != "" {
+		policy, err := spec.LoadAdmissionPolicy(path)
+		if err != nil {
+			return err
+		}
+		c.Context = context.WithValue(c.Context, policyKey{}, policy)
+	}
+	return nil
+}
+
+// admissionPolicy returns the policy given with --policy, or otherwise the
+// host's.
+func (h *Host) admissionPolicy(c *cli.Context) *spec.AdmissionPolicy {
+	if policy, ok := c.Context.Value(policyKey{}).(*spec.AdmissionPolicy); ok {
+		return policy
+	}
+	return h.opts.AdmissionPolicy
+}
+
+func (h *Host) newContext(c *cli.Context) (*Context, error) {
+	reference := c.Args().First()
+	if reference == "" {
+		return nil, ErrMissingReference
+	}
+
+	resolved, err := h.resolve(c, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Context{
+		Context:   c.Context,
+		CLI:       c,
+		Reference: reference,
+		Args:      c.Args().Tail(),
+		Spec:      resolved.spec,
+		Digest:    resolved.digest,
+		Client:    h.opts.Client,
+		Logger:    h.opts.Logger,
+		Output:    h.opts.Output,
+		Stdout:    c.App.Writer,
+		lookupEnv: h.opts.LookupEnv,
+	}, nil
+}
+
+type resolvedKey struct{}
+
+// resolvedSpec is a spec resolved from a command argument.
+type resolvedSpec struct {
+	reference string
+	spec      *common.RuntimeSpec
+	digest    string
+}
+
+// resolve reads the spec named by reference: a spec file on disk, or
+// otherwise an artifact reference pulled with the host's client. The spec
+// must satisfy the admission policy, if any. A spec already resolved for
+// reference by the enclosing command is reused.
+func (h *Host) resolve(c *cli.Context, reference string) (*resolvedSpec, error) {
+	if r, ok := c.Context.Value(resolvedKey{}).(*resolvedSpec); ok && r.reference == reference {
+		return r, nil
+	}
+
+	resolved, err := h.load(c, reference)
+	if err != nil {
+		return nil, err
+	}
+	if policy := h.admissionPolicy(c); policy != nil {
+		if err := policy.Check(resolved.spec); err != nil {
+			return nil, fmt.Errorf("%s rejected by admission policy: %w", reference, err)
+		}
+	}
+	return resolved, nil
+}
+
+// load reads the spec file reference names or, if there is no such file and
+// reference is an artifact reference, pulls it.
+func (h *Host) load(c *cli.Context, reference string) (*resolvedSpec, error) {
+	data, err := os.ReadFile(reference)
+	if err == nil {
+		s, err := spec.ParseYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spec %s: %w", reference, err)
+		}
+		return &resolvedSpec{reference: reference, spec: s}, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if _, refErr := registry.ParseReference(reference); refErr != nil {
+		return nil, err
+	}
+
+	art, pullErr := h.opts.Client.Pull(c.Context, h.pin(reference))
+	if pullErr != nil {
+		return nil, fmt.Errorf("%w; %w", err, pullErr)
+	}
+	if len(art.Layers) == 0 {
+		return nil, fmt.Errorf("no spec layer found in artifact")
+	}
+	s, err := spec.ParseYAML(art.Layers[0].Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", reference, err)
+	}
+	h.opts.Logger.Debug("resolved runtime spec", "reference", reference, "digest", art.Digest)
+
+	return &resolvedSpec{reference: reference, spec: s, digest: art.Digest}, nil
+}
+
+// pin returns reference with its tag replaced by the digest the host that
+// started this plugin resolved it to, so that the plugin deploys the same
+// artifact even if the tag has moved since.
+func (h *Host) pin(reference string) string {
+	if reference != h.pinned.reference || h.pinned.digest == "" {
+		return reference
+	}
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return reference
+	}
+	ref.Reference = h.pinned.digest
+	return ref.String()
+}
@@ -0,0 +1,151 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+const contextTestSpec = `apiVersion: eigenruntime.io/v1alpha1
+kind: Runtime
+name: example-runtime
+version: 1.0.0
+spec:
+  performer:
+    registry: ghcr.io/example/performer
+    digest: sha256:abc123
+    env:
+      - name: API_KEY
+        type: secret
+        required: true
+      - name: LOG_LEVEL
+`
+
+// contextPlugin runs a single typed action.
+type contextPlugin struct {
+	action func(*Context) error
+}
+
+func (p *contextPlugin) Name() string                     { return "deployer" }
+func (p *contextPlugin) Version() string                  { return "1.0.0" }
+func (p *contextPlugin) DescribeCommands() []*cli.Command { return nil }
+func (p *contextPlugin) GetCommands() []*cli.Command      { return nil }
+func (p *contextPlugin) RemoveCommands() []*cli.Command   { return nil }
+
+func (p *contextPlugin) RunCommands() []*cli.Command {
+	return []*cli.Command{{Name: "deploy", Action: Action(p.action)}}
+}
+
+func TestAction(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(contextTestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	env := map[string]string{"API_KEY": "secret"}
+	h := NewHost(HostOptions{LookupEnv: func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}})
+
+	var got *Context
+	p := &contextPlugin{action: func(ctx *Context) error {
+		got = ctx
+		return ctx.Print(map[string]string{"name": ctx.Spec.Name})
+	}}
+	if err := h.Register(p); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	app, out := newTestApp(t, h)
+
+	if err := app.Run([]string{"eigenruntime", "run", "deployer", specPath, "extra"}); err != nil {
+		t.Fatalf("Failed to run command: %v", err)
+	}
+
+	if got.Reference != specPath || got.Digest != "" || strings.Join(got.Args, " ") != "extra" {
+		t.Errorf("Unexpected context: reference %q, digest %q, args %v", got.Reference, got.Digest, got.Args)
+	}
+	if got.Client != h.opts.Client || got.Logger == nil {
+		t.Error("Expected the host's client and logger")
+	}
+	if out.String() != "{\n  \"name\": \"example-runtime\"\n}\n" {
+		t.Errorf("Unexpected output: %q", out.String())
+	}
+
+	vars, err := got.Env("performer")
+	if err != nil {
+		t.Fatalf("Failed to resolve env: %v", err)
+	}
+	if len(vars) != 1 || vars["API_KEY"] != "secret" {
+		t.Errorf("Expected only API_KEY to be resolved, got %v", vars)
+	}
+
+	delete(env, "API_KEY")
+	if _, err := got.Env("performer"); err == nil || !strings.Contains(err.Error(), "API_KEY") {
+		t.Errorf("Expected missing required variable error, got %v", err)
+	}
+	if _, err := got.Env("executor"); err == nil {
+		t.Error("Expected error for unknown component")
+	}
+
+	if err := app.Run([]string{"eigenruntime", "run", "deployer"}); !errors.Is(err, ErrMissingReference) {
+		t.Errorf("Expected ErrMissingReference, got %v", err)
+	}
+	// A missing file that is not an artifact reference is not pulled.
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if err := app.Run([]string{"eigenruntime", "run", "deployer", missing}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+}
+
+func TestActionAdmissionPolicy(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(contextTestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	policyPath := filepath.Join(dir, "policy.yaml")
+	policyYAML := "rules:\n  - name: tee\n    forEach: components\n    require: component.resources.teeEnabled == true\n"
+	if err := os.WriteFile(policyPath, []byte(policyYAML), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	policy, err := spec.ParseAdmissionPolicy([]byte("rules:\n  - name: name\n    require: spec.name == \"other\"\n"))
+	if err != nil {
+		t.Fatalf("Failed to parse policy: %v", err)
+	}
+
+	ran := false
+	p := &contextPlugin{action: func(ctx *Context) error {
+		ran = true
+		return nil
+	}}
+	h := NewHost(HostOptions{AdmissionPolicy: policy})
+	if err := h.Register(p); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	app, _ := newTestApp(t, h)
+
+	for _, tt := range []struct {
+		args []string
+		rule string
+	}{
+		{args: []string{"eigenruntime", "run", "deployer", specPath}, rule: "name"},
+		{args: []string{"eigenruntime", "run", "--policy", policyPath, "deployer", specPath}, rule: "tee"},
+	} {
+		var validationErr *spec.ValidationError
+		if err := app.Run(tt.args); !errors.As(err, &validationErr) {
+			t.Fatalf("Expected %v to be rejected, got %v", tt.args, err)
+		}
+		if validationErr.Violations[0].Rule != tt.rule {
+			t.Errorf("Expected %v to violate %s, got %v", tt.args, tt.rule, validationErr.Violations)
+		}
+	}
+	if ran {
+		t.Error("Expected the command not to run for a rejected spec")
+	}
+}
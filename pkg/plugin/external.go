@@ -0,0 +1,382 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// ExecutablePrefix is the file name prefix of external plugin
+	// executables, e.g. "eigenruntime-plugin-avs".
+	ExecutablePrefix = "eigenruntime-plugin-"
+
+	// ProtocolVersion is the version of the stdio protocol spoken between
+	// the host and external plugins.
+	ProtocolVersion = 1
+
+	// ProtocolEnv is set to the protocol version when the host starts a
+	// plugin for the handshake. A plugin started with it set reads one
+	// JSON-RPC request from stdin and writes the response to stdout.
+	ProtocolEnv = "EIGENRUNTIME_PLUGIN_PROTOCOL"
+
+	// DefaultHandshakeTimeout bounds how long a plugin may take to answer
+	// the handshake. Plugins are discovered on every invocation of the CLI,
+	// so it is kept short.
+	DefaultHandshakeTimeout = 2 * time.Second
+
+	handshakeMethod = "handshake"
+	jsonRPCVersion  = "2.0"
+)
+
+// Environment variables set by the host when it runs a command of an
+// external plugin. Serve creates the plugin's client and admission policy
+// from them, so that the plugin resolves specs the way the host does.
+const (
+	// PlainHTTPEnv, RegistryConfigEnv and CacheDirEnv carry the host's
+	// registry settings. Credentials are shared through the Docker config
+	// file that DOCKER_CONFIG locates.
+	PlainHTTPEnv      = "EIGENRUNTIME_PLAIN_HTTP"
+	RegistryConfigEnv = "EIGENRUNTIME_REGISTRY_CONFIG"
+	CacheDirEnv       = "EIGENRUNTIME_CACHE_DIR"
+
+	// PolicyEnv is the admission policy file given with --policy.
+	PolicyEnv = "EIGENRUNTIME_POLICY"
+
+	// OutputEnv is the output format, "text" or one accepted by
+	// output.Parse, used by Context.Print.
+	OutputEnv = "EIGENRUNTIME_OUTPUT"
+
+	// ReferenceEnv and DigestEnv are set when the host routed a spec to the
+	// plugin: the reference it was given and the digest it resolved, which
+	// the plugin pulls instead of the possibly moved tag.
+	ReferenceEnv = "EIGENRUNTIME_REFERENCE"
+	DigestEnv    = "EIGENRUNTIME_DIGEST"
+)
+
+// ErrIncompatibleProtocol is returned when an external plugin speaks a
+// different protocol version than the host.
+var ErrIncompatibleProtocol = errors.New("incompatible plugin protocol")
+
+// HandshakeRequest is sent by the host to an external plugin.
+type HandshakeRequest struct {
+	ProtocolVersion int `json:"protocolVersion"`
+}
+
+// HandshakeResponse describes an external plugin. Commands is keyed by verb,
+// e.g. "run" or "status".
+type HandshakeResponse struct {
+	ProtocolVersion int                      `json:"protocolVersion"`
+	Name            string                   `json:"name"`
+	Version         string                   `json:"version"`
+	Capabilities    Capabilities             `json:"capabilities"`
+	Commands        map[string][]CommandInfo `json:"commands,omitempty"`
+}
+
+// CommandInfo describes a command of an external plugin.
+type CommandInfo struct {
+	Name        string        `json:"name"`
+	Usage       string        `json:"usage,omitempty"`
+	Aliases     []string      `json:"aliases,omitempty"`
+	Subcommands []CommandInfo `json:"subcommands,omitempty"`
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// ExternalPlugin is a plugin running as a separate executable. Its commands
+// run the executable with the verb, the command path and the remaining
+// arguments, e.g. "eigenruntime-plugin-avs run start --flag value",
+// connected to the app's reader, writer and error writer.
+type ExternalPlugin struct {
+	Path string
+
+	name     string
+	version  string
+	caps     Capabilities
+	commands map[string][]CommandInfo
+}
+
+// LoadExternal starts the executable at path, performs the handshake and
+// returns the plugin it describes.
+func LoadExternal(ctx context.Context, path string) (*ExternalPlugin, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHandshakeTimeout)
+	defer cancel()
+
+	params, err := json.Marshal(HandshakeRequest{ProtocolVersion: ProtocolVersion})
+	if err != nil {
+		return nil, err
+	}
+	req, err := json.Marshal(rpcRequest{JSONRPC: jsonRPCVersion, ID: 1, Method: handshakeMethod, Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Env = append(os.Environ(), ProtocolEnv+"="+strconv.Itoa(ProtocolVersion))
+	cmd.Stdin = bytes.NewReader(append(req, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("failed to start plugin %s: %w: %s", path, err, msg)
+		}
+		return nil, fmt.Errorf("failed to start plugin %s: %w", path, err)
+	}
+
+	var resp rpcResponse
+	if err := json.NewDecoder(&stdout).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read handshake from plugin %s: %w", path, err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("plugin %s rejected handshake: %w", path, resp.Error)
+	}
+
+	var hs HandshakeResponse
+	if err := json.Unmarshal(resp.Result, &hs); err != nil {
+		return nil, fmt.Errorf("failed to parse handshake from plugin %s: %w", path, err)
+	}
+	if hs.ProtocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf("%w: plugin %s speaks version %d, host speaks version %d", ErrIncompatibleProtocol, path, hs.ProtocolVersion, ProtocolVersion)
+	}
+	if hs.Name == "" {
+		return nil, fmt.Errorf("plugin %s did not report a name", path)
+	}
+
+	return &ExternalPlugin{
+		Path:     path,
+		name:     hs.Name,
+		version:  hs.Version,
+		caps:     hs.Capabilities,
+		commands: hs.Commands,
+	}, nil
+}
+
+// Discover finds external plugin executables in dirs, which defaults to
+// the directories on PATH, and loads them concurrently. Like PATH lookup,
+// the first executable with a given file name wins. Plugins that fail to
+// load are skipped and reported in the returned error.
+func Discover(ctx context.Context, dirs ...string) ([]EigenRuntimePlugin, error) {
+	if len(dirs) == 0 {
+		dirs = filepath.SplitList(os.Getenv("PATH"))
+	}
+
+	paths := findExecutables(dirs)
+	loaded := make([]*ExternalPlugin, len(paths))
+	errs := make([]error, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			loaded[i], errs[i] = LoadExternal(ctx, path)
+		}(i, path)
+	}
+	wg.Wait()
+
+	var plugins []EigenRuntimePlugin
+	for i, p := range loaded {
+		if errs[i] == nil {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins, errors.Join(errs...)
+}
+
+func findExecutables(dirs []string) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		var names []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, ExecutablePrefix) || seen[name] {
+				continue
+			}
+			info, err := os.Stat(filepath.Join(dir, name))
+			if err != nil || !isExecutable(info) {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		for _, name := range names {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	return paths
+}
+
+func isExecutable(info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(info.Name()), ".exe")
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+func (p *ExternalPlugin) Name() string    { return p.name }
+func (p *ExternalPlugin) Version() string { return p.version }
+
+func (p *ExternalPlugin) Capabilities() Capabilities { return p.caps }
+
+func (p *ExternalPlugin) DescribeCommands() []*cli.Command { return p.verbCommands("describe") }
+func (p *ExternalPlugin) GetCommands() []*cli.Command      { return p.verbCommands("get") }
+func (p *ExternalPlugin) RunCommands() []*cli.Command      { return p.verbCommands("run") }
+func (p *ExternalPlugin) RemoveCommands() []*cli.Command   { return p.verbCommands("remove") }
+
+func (p *ExternalPlugin) RegistrationCommands() []*cli.Command {
+	return p.verbCommands("register")
+}
+
+func (p *ExternalPlugin) DeregistrationCommands() []*cli.Command {
+	return p.verbCommands("deregister")
+}
+
+func (p *ExternalPlugin) StatusCommands() []*cli.Command  { return p.verbCommands("status") }
+func (p *ExternalPlugin) LogsCommands() []*cli.Command    { return p.verbCommands("logs") }
+func (p *ExternalPlugin) UpgradeCommands() []*cli.Command { return p.verbCommands("upgrade") }
+
+func (p *ExternalPlugin) verbCommands(verb string) []*cli.Command {
+	infos := p.commands[verb]
+	commands := make([]*cli.Command, 0, len(infos))
+	for _, info := range infos {
+		commands = append(commands, p.command(info, []string{verb}))
+	}
+	return commands
+}
+
+// command converts info into a command. Leaf commands skip flag parsing so
+// that every argument is forwarded to the plugin untouched.
+func (p *ExternalPlugin) command(info CommandInfo, parent []string) *cli.Command {
+	path := append(append([]string(nil), parent...), info.Name)
+	cmd := &cli.Command{
+		Name:    info.Name,
+		Usage:   info.Usage,
+		Aliases: info.Aliases,
+	}
+
+	if len(info.Subcommands) > 0 {
+		for _, sub := range info.Subcommands {
+			cmd.Subcommands = append(cmd.Subcommands, p.command(sub, path))
+		}
+		return cmd
+	}
+
+	cmd.SkipFlagParsing = true
+	cmd.Action = func(c *cli.Context) error {
+		return p.invoke(c, append(path, c.Args().Slice()...))
+	}
+	return cmd
+}
+
+func (p *ExternalPlugin) invoke(c *cli.Context, args []string) error {
+	if err := p.admit(c, args[0]); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(c.Context, p.Path, args...)
+	cmd.Env = append(os.Environ(), forwardedEnv(c)...)
+	cmd.Stdin = c.App.Reader
+	cmd.Stdout = c.App.Writer
+	cmd.Stderr = c.App.ErrWriter
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return cli.Exit("", exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to run plugin %s: %w", p.name, err)
+	}
+	return nil
+}
+
+// admit checks the runtime named by the first argument of a command of a
+// verb that deploys against the admission policy before the plugin starts,
+// as a policy given only in HostOptions cannot be forwarded to the plugin.
+// The runtime is then forwarded as if the host had routed it.
+func (p *ExternalPlugin) admit(c *cli.Context, verbName string) error {
+	h, ok := c.Context.Value(hostKey{}).(*Host)
+	if !ok || !deploys(verbName) || h.admissionPolicy(c) == nil {
+		return nil
+	}
+
+	reference := c.Args().First()
+	if reference == "" || strings.HasPrefix(reference, "-") {
+		return fmt.Errorf("%w: %s %s commands must be given the runtime as their first argument when an admission policy is set", ErrMissingReference, verbName, p.name)
+	}
+	resolved, err := h.resolve(c, reference)
+	if err != nil {
+		return err
+	}
+	c.Context = context.WithValue(c.Context, resolvedKey{}, resolved)
+	return nil
+}
+
+// forwardedEnv returns the variables that pass the host's settings, and the
+// runtime it resolved, to an external plugin command.
+func forwardedEnv(c *cli.Context) []string {
+	h, ok := c.Context.Value(hostKey{}).(*Host)
+	if !ok {
+		return nil
+	}
+
+	var resolved resolvedSpec
+	if r, ok := c.Context.Value(resolvedKey{}).(*resolvedSpec); ok {
+		resolved = *r
+	}
+	return []string{
+		PlainHTTPEnv + "=" + strconv.FormatBool(h.opts.PlainHTTP),
+		RegistryConfigEnv + "=" + h.opts.RegistryConfig,
+		CacheDirEnv + "=" + h.opts.CacheDir,
+		PolicyEnv + "=" + c.String(policyFlag.Name),
+		OutputEnv + "=" + h.opts.OutputFormat,
+		ReferenceEnv + "=" + resolved.reference,
+		DigestEnv + "=" + resolved.digest,
+	}
+}
@@ -0,0 +1,416 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/internal/registrytest"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/output"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+const helperPluginEnv = "EIGENRUNTIME_TEST_HELPER_PLUGIN"
+
+// TestMain lets the test binary act as an external plugin when started by
+// the host under a plugin executable name.
+func TestMain(m *testing.M) {
+	if os.Getenv(helperPluginEnv) == "1" {
+		if err := Serve(helperPlugin{}, os.Args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type helperPlugin struct{}
+
+func (helperPlugin) Name() string    { return "helper" }
+func (helperPlugin) Version() string { return "2.1.0" }
+
+func (helperPlugin) Capabilities() Capabilities {
+	return Capabilities{APIVersions: "^1.0", Specs: []SpecType{{Kind: "Helper"}}}
+}
+
+func (helperPlugin) DescribeCommands() []*cli.Command { return nil }
+func (helperPlugin) RemoveCommands() []*cli.Command   { return nil }
+
+func (helperPlugin) RunCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "start",
+			Usage: "Start a runtime",
+			Flags: []cli.Flag{&cli.StringFlag{Name: "env"}},
+			Action: func(c *cli.Context) error {
+				if c.Args().First() == "fail" {
+					return cli.Exit("failed", 3)
+				}
+				fmt.Fprintf(c.App.Writer, "started %s env=%s\n", strings.Join(c.Args().Slice(), ","), c.String("env"))
+				return nil
+			},
+		},
+	}
+}
+
+func (helperPlugin) GetCommands() []*cli.Command {
+	return []*cli.Command{
+		{Name: "status", Usage: "Show status", Action: func(c *cli.Context) error {
+			fmt.Fprintln(c.App.Writer, "healthy")
+			return nil
+		}},
+		{Name: "logs", Aliases: []string{"log"}, Action: func(c *cli.Context) error { return nil }},
+	}
+}
+
+func (helperPlugin) StatusCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "show",
+			Usage: "Show the status of a runtime",
+			Action: Action(func(ctx *Context) error {
+				fmt.Fprintf(ctx.Stdout, "%s %s pinned=%s\n", ctx.Spec.Name, ctx.Digest, os.Getenv(DigestEnv))
+				return nil
+			}),
+		},
+	}
+}
+
+func (helperPlugin) UpgradeCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "apply",
+			Usage: "Upgrade a runtime after confirmation on stdin",
+			Action: Action(func(ctx *Context) error {
+				confirmation, err := bufio.NewReader(ctx.CLI.App.Reader).ReadString('\n')
+				if err != nil {
+					return err
+				}
+				return ctx.Print(map[string]string{"name": ctx.Spec.Name, "confirmation": strings.TrimSpace(confirmation)})
+			}),
+		},
+	}
+}
+
+// installHelper links the test binary into dir under a plugin name.
+func installHelper(t *testing.T, dir string) {
+	t.Helper()
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("Failed to locate test binary: %v", err)
+	}
+	if err := os.Symlink(exe, filepath.Join(dir, ExecutablePrefix+"helper")); err != nil {
+		t.Skipf("Symlinks unavailable: %v", err)
+	}
+	t.Setenv(helperPluginEnv, "1")
+}
+
+func writeScript(t *testing.T, path, script string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugin scripts require a POSIX shell")
+	}
+
+	first, second := t.TempDir(), t.TempDir()
+	installHelper(t, first)
+
+	// Shadowed by the helper in the earlier directory.
+	writeScript(t, filepath.Join(second, ExecutablePrefix+"helper"), "exit 1")
+	// Not executable.
+	if err := os.WriteFile(filepath.Join(first, ExecutablePrefix+"data"), nil, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	// Speaks a newer protocol.
+	writeScript(t, filepath.Join(second, ExecutablePrefix+"future"),
+		`echo '{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":2,"name":"future"}}'`)
+
+	plugins, err := Discover(context.Background(), first, second)
+	if !errors.Is(err, ErrIncompatibleProtocol) {
+		t.Errorf("Expected ErrIncompatibleProtocol for the future plugin, got %v", err)
+	}
+	if len(plugins) != 1 {
+		t.Fatalf("Expected 1 plugin, got %d", len(plugins))
+	}
+	if plugins[0].Name() != "helper" || plugins[0].Version() != "2.1.0" {
+		t.Errorf("Expected helper 2.1.0, got %s %s", plugins[0].Name(), plugins[0].Version())
+	}
+	if caps := capabilities(plugins[0]); caps.APIVersions != "^1.0" || len(caps.Specs) != 1 || caps.Specs[0].Kind != "Helper" {
+		t.Errorf("Expected capabilities from the handshake, got %+v", caps)
+	}
+}
+
+func TestDiscoverConcurrent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugin scripts require a POSIX shell")
+	}
+
+	// Each plugin only answers once the other has started, so loading them
+	// one at a time would run into the handshake timeout.
+	dir, marks := t.TempDir(), t.TempDir()
+	for _, pair := range [][2]string{{"left", "right"}, {"right", "left"}} {
+		writeScript(t, filepath.Join(dir, ExecutablePrefix+pair[0]), fmt.Sprintf(
+			`touch %[1]s/%[2]s; while [ ! -e %[1]s/%[3]s ]; do sleep 0.01; done; echo '{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":1,"name":"%[2]s"}}'`,
+			marks, pair[0], pair[1]))
+	}
+
+	plugins, err := Discover(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Failed to discover plugins: %v", err)
+	}
+	if len(plugins) != 2 || plugins[0].Name() != "left" || plugins[1].Name() != "right" {
+		t.Errorf("Expected plugins left and right in order, got %v", plugins)
+	}
+}
+
+func TestExternalPluginCommands(t *testing.T) {
+	dir := t.TempDir()
+	installHelper(t, dir)
+
+	plugins, err := Discover(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Failed to discover plugins: %v", err)
+	}
+
+	h := NewHost(HostOptions{})
+	if err := h.Register(plugins...); err != nil {
+		t.Fatalf("Failed to register plugins: %v", err)
+	}
+	app, out := newTestApp(t, h)
+	app.ExitErrHandler = func(*cli.Context, error) {}
+
+	tests := []struct {
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{args: []string{"run", "helper", "--env", "prod", "my-runtime"}, expected: "started my-runtime env=prod\n"},
+		{args: []string{"get", "helper", "status"}, expected: "healthy\n"},
+		{args: []string{"get", "helper", "log"}},
+		{args: []string{"run", "helper", "fail"}, exitCode: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			out.Reset()
+			err := app.Run(append([]string{"eigenruntime"}, tt.args...))
+			if tt.exitCode != 0 {
+				var exitErr cli.ExitCoder
+				if !errors.As(err, &exitErr) || exitErr.ExitCode() != tt.exitCode {
+					t.Fatalf("Expected exit code %d, got %v", tt.exitCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to run command: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("Expected output %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestExternalPluginRegistry(t *testing.T) {
+	dir := t.TempDir()
+	installHelper(t, dir)
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	reg := registrytest.New(t)
+	image := reg.PutImage(t, "example/performer", []byte("performer layer"))
+	specContent := strings.Replace(fmt.Sprintf(registrytest.SpecYAML, reg.Host(), image.Digest), "kind: Runtime", "kind: Helper", 1)
+	artifact := reg.PutArtifact(t, "example/helper", "v1", []byte(specContent))
+	ref := reg.Host() + "/example/helper:v1"
+
+	plugins, err := Discover(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Failed to discover plugins: %v", err)
+	}
+	// The registry only speaks plain HTTP, so the plugin can only pull from
+	// it with the settings forwarded by the host.
+	h := NewHost(HostOptions{Client: client.NewClient(client.ClientOptions{PlainHTTP: true}), PlainHTTP: true})
+	if err := h.Register(plugins...); err != nil {
+		t.Fatalf("Failed to register plugins: %v", err)
+	}
+	app, out := newTestApp(t, h)
+
+	expected := "example-runtime " + artifact.Digest.String() + " pinned=\n"
+	if err := app.Run([]string{"eigenruntime", "status", "helper", ref}); err != nil {
+		t.Fatalf("Failed to run plugin command: %v", err)
+	}
+	if out.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, out.String())
+	}
+
+	// Routed by kind, the plugin is given the digest the host resolved.
+	out.Reset()
+	if err := app.Run([]string{"eigenruntime", "status", ref}); err != nil {
+		t.Fatalf("Failed to run routed plugin command: %v", err)
+	}
+	expected = "example-runtime " + artifact.Digest.String() + " pinned=" + artifact.Digest.String() + "\n"
+	if out.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, out.String())
+	}
+}
+
+func TestExternalPluginIO(t *testing.T) {
+	dir := t.TempDir()
+	installHelper(t, dir)
+
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(contextTestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	plugins, err := Discover(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Failed to discover plugins: %v", err)
+	}
+	h := NewHost(HostOptions{Output: output.YAML{}, OutputFormat: "yaml"})
+	if err := h.Register(plugins...); err != nil {
+		t.Fatalf("Failed to register plugins: %v", err)
+	}
+	app, out := newTestApp(t, h)
+	app.Reader = strings.NewReader("yes\n")
+
+	if err := app.Run([]string{"eigenruntime", "upgrade", "helper", specPath}); err != nil {
+		t.Fatalf("Failed to run plugin command: %v", err)
+	}
+	expected := "confirmation: yes\nname: example-runtime\n"
+	if out.String() != expected {
+		t.Errorf("Expected the plugin to read the app's input and print YAML %q, got %q", expected, out.String())
+	}
+}
+
+func TestExternalPluginAdmissionPolicy(t *testing.T) {
+	dir := t.TempDir()
+	installHelper(t, dir)
+
+	specPath := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(specPath, []byte(contextTestSpec), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+
+	plugins, err := Discover(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Failed to discover plugins: %v", err)
+	}
+
+	for _, tt := range []struct {
+		require string
+		args    []string
+		wantErr bool
+	}{
+		{require: `spec.name == "other"`, args: []string{"eigenruntime", "upgrade", "helper", specPath}, wantErr: true},
+		{require: `spec.name == "example-runtime"`, args: []string{"eigenruntime", "upgrade", "helper", specPath}},
+		{require: `spec.name == "example-runtime"`, args: []string{"eigenruntime", "upgrade", "helper"}, wantErr: true},
+	} {
+		policy, err := spec.ParseAdmissionPolicy([]byte("rules:\n  - name: name\n    require: " + tt.require + "\n"))
+		if err != nil {
+			t.Fatalf("Failed to parse policy: %v", err)
+		}
+		// The policy only exists in the host, so the host has to check the
+		// spec before starting the plugin.
+		h := NewHost(HostOptions{AdmissionPolicy: policy, Output: output.YAML{}, OutputFormat: "yaml"})
+		if err := h.Register(plugins...); err != nil {
+			t.Fatalf("Failed to register plugins: %v", err)
+		}
+		app, out := newTestApp(t, h)
+		app.Reader = strings.NewReader("yes\n")
+
+		err = app.Run(tt.args)
+		if tt.wantErr {
+			if err == nil || out.Len() != 0 {
+				t.Errorf("Expected %v to be rejected before the plugin ran, got %v and output %q", tt.args, err, out.String())
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Failed to run plugin command: %v", err)
+		}
+		if out.Len() == 0 {
+			t.Errorf("Expected %v to run the plugin", tt.args)
+		}
+	}
+}
+
+func TestHostPin(t *testing.T) {
+	const digest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	h := NewHost(HostOptions{})
+	h.pinned = resolvedSpec{reference: "ghcr.io/example/runtime:v1", digest: digest}
+
+	tests := map[string]string{
+		"ghcr.io/example/runtime:v1": "ghcr.io/example/runtime@" + digest,
+		"ghcr.io/example/runtime:v2": "ghcr.io/example/runtime:v2",
+		"./spec.yaml":                "./spec.yaml",
+	}
+	for reference, expected := range tests {
+		if got := h.pin(reference); got != expected {
+			t.Errorf("Expected %s to be pinned to %s, got %s", reference, expected, got)
+		}
+	}
+}
+
+func TestServeHandshake(t *testing.T) {
+	tests := []struct {
+		name    string
+		request string
+		wantErr string
+	}{
+		{name: "supported", request: `{"jsonrpc":"2.0","id":7,"method":"handshake","params":{"protocolVersion":1}}`},
+		{name: "unsupported version", request: `{"jsonrpc":"2.0","id":7,"method":"handshake","params":{"protocolVersion":2}}`, wantErr: "unsupported protocol version 2"},
+		{name: "unknown method", request: `{"jsonrpc":"2.0","id":7,"method":"invoke"}`, wantErr: `unknown method "invoke"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := serveHandshake(helperPlugin{}, strings.NewReader(tt.request), &out); err != nil {
+				t.Fatalf("Failed to serve handshake: %v", err)
+			}
+
+			var resp rpcResponse
+			if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if resp.ID != 7 {
+				t.Errorf("Expected response id 7, got %d", resp.ID)
+			}
+
+			if tt.wantErr != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Message, tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, resp.Error)
+				}
+				return
+			}
+
+			var hs HandshakeResponse
+			if err := json.Unmarshal(resp.Result, &hs); err != nil {
+				t.Fatalf("Failed to parse handshake: %v", err)
+			}
+			if hs.Name != "helper" || len(hs.Commands["get"]) != 2 || hs.Commands["get"][1].Aliases[0] != "log" {
+				t.Errorf("Unexpected handshake: %+v", hs)
+			}
+			if _, ok := hs.Commands["describe"]; ok {
+				t.Error("Expected verbs without commands to be omitted")
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/output"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+// ErrDuplicatePlugin is returned when two plugins share a name.
+var ErrDuplicatePlugin = errors.New("duplicate plugin")
+
+// verb is a top-level command under which every plugin can mount commands,
+// so that the CLI reads "eigenruntime <verb> <plugin> ...".
+type verb struct {
+	name     string
+	usage    string
+	flags    []cli.Flag
+	commands func(p EigenRuntimePlugin) []*cli.Command
+}
+
+// policyFlag is accepted by the verbs that deploy a runtime.
+var policyFlag = &cli.StringFlag{Name: "policy", Usage: "reject specs that violate the admission policy in `FILE`"}
+
+var verbs = []verb{
+	{name: "describe", usage: "Describe resources managed by a plugin", commands: EigenRuntimePlugin.DescribeCommands},
+	{name: "get", usage: "Get resources managed by a plugin", commands: EigenRuntimePlugin.GetCommands},
+	{name: "run", usage: "Run a runtime with a plugin", flags: []cli.Flag{policyFlag}, commands: EigenRuntimePlugin.RunCommands},
+	{name: "remove", usage: "Remove resources managed by a plugin", commands: EigenRuntimePlugin.RemoveCommands},
+	{name: "register", usage: "Register a runtime with a plugin", commands: optional(Registerer.RegistrationCommands)},
+	{name: "deregister", usage: "Deregister a runtime from a plugin", commands: optional(Deregisterer.DeregistrationCommands)},
+	{name: "status", usage: "Show the status of a runtime managed by a plugin", commands: optional(StatusReporter.StatusCommands)},
+	{name: "logs", usage: "Show the logs of a runtime managed by a plugin", commands: optional(LogStreamer.LogsCommands)},
+	{name: "upgrade", usage: "Upgrade a runtime managed by a plugin", flags: []cli.Flag{policyFlag}, commands: optional(Upgrader.UpgradeCommands)},
+}
+
+// deploys reports whether the verb named name deploys a runtime, so that
+// its commands are subject to the admission policy.
+func deploys(name string) bool {
+	for _, v := range verbs {
+		if v.name != name {
+			continue
+		}
+		for _, f := range v.flags {
+			if f == policyFlag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// optional adapts the method of an optional interface to a verb, returning
+// no commands for plugins that do not implement it.
+func optional[T any](commands func(T) []*cli.Command) func(EigenRuntimePlugin) []*cli.Command {
+	return func(p EigenRuntimePlugin) []*cli.Command {
+		if impl, ok := p.(T); ok {
+			return commands(impl)
+		}
+		return nil
+	}
+}
+
+// HostOptions configures the Context passed to plugin commands built with
+// Action.
+type HostOptions struct {
+	// Client pulls runtime specs. Defaults to a client with default options.
+	Client *client.Client
+
+	// Logger defaults to discarding all records.
+	Logger *slog.Logger
+
+	// Output formats results printed with Context.Print. Defaults to JSON.
+	Output output.Formatter
+	// OutputFormat is the format Output was created from: "text" or one
+	// accepted by output.Parse. It is forwarded to external plugins, so that
+	// their commands print in the same format.
+	OutputFormat string
+
+	// LookupEnv resolves component environment variables. Defaults to
+	// os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+
+	// AdmissionPolicy, when set, must be satisfied by every spec resolved
+	// for a plugin command. A policy given with --policy to the run or
+	// upgrade verb takes its place for that command, and is also forwarded
+	// to external plugins. The run and upgrade commands of external plugins
+	// must then take the runtime as their first argument: the host resolves
+	// and checks it before starting the plugin.
+	AdmissionPolicy *spec.AdmissionPolicy
+
+	// PlainHTTP, RegistryConfig and CacheDir are the settings Client was
+	// created with. They are forwarded to external plugins, whose commands
+	// create an equivalent client from them; see PlainHTTPEnv.
+	PlainHTTP      bool
+	RegistryConfig string
+	CacheDir       string
+}
+
+// Host collects plugins and mounts their commands into a CLI.
+type Host struct {
+	opts    HostOptions
+	plugins map[string]EigenRuntimePlugin
+	caps    map[string]Capabilities
+
+	// pinned is the runtime resolved by the host that started this plugin,
+	// which resolve pulls by digest.
+	pinned resolvedSpec
+}
+
+// NewHost returns a host with no plugins.
+func NewHost(opts HostOptions) *Host {
+	h := &Host{
+		plugins: make(map[string]EigenRuntimePlugin),
+		caps:    make(map[string]Capabilities),
+	}
+	h.Configure(opts)
+	return h
+}
+
+// Configure replaces the host's options, e.g. once global flags have been
+// parsed after plugin commands were mounted.
+func (h *Host) Configure(opts HostOptions) {
+	if opts.Client == nil {
+		opts.Client = client.NewClient(client.ClientOptions{})
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	if opts.Output == nil {
+		opts.Output = output.JSON{}
+	}
+	if opts.LookupEnv == nil {
+		opts.LookupEnv = os.LookupEnv
+	}
+	h.opts = opts
+}
+
+// Register adds plugins to the host. It fails without registering any of
+// them if a name is empty or already taken, a plugin does not support the
+// host's APIVersion, or two plugins claim the same spec type. Plugins that
+// do not declare their capabilities are registered with a warning logged.
+func (h *Host) Register(plugins ...EigenRuntimePlugin) error {
+	pending := make(map[string]Capabilities, len(plugins))
+	warnings := make(map[string][]string)
+	for _, p := range plugins {
+		name := p.Name()
+		if name == "" {
+			return fmt.Errorf("plugin name cannot be empty")
+		}
+		if _, ok := h.plugins[name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicatePlugin, name)
+		}
+		if _, ok := pending[name]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicatePlugin, name)
+		}
+
+		caps := capabilities(p)
+		w, err := checkCompatibility(p, caps)
+		if err != nil {
+			return err
+		}
+		for _, t := range caps.Specs {
+			if owner, ok := h.kindClaimant(t, pending); ok {
+				return fmt.Errorf("%w: %s is claimed by both %s and %s", ErrKindClaimed, t, owner, name)
+			}
+		}
+
+		pending[name] = caps
+		warnings[name] = w
+	}
+
+	for _, p := range plugins {
+		name := p.Name()
+		for _, w := range warnings[name] {
+			h.opts.Logger.Warn(w, "plugin", name)
+		}
+		h.plugins[name] = p
+		h.caps[name] = pending[name]
+	}
+	return nil
+}
+
+// Lookup returns the plugin registered under name.
+func (h *Host) Lookup(name string) (EigenRuntimePlugin, bool) {
+	p, ok := h.plugins[name]
+	return p, ok
+}
+
+// Plugins returns every registered plugin, ordered by name.
+func (h *Host) Plugins() []EigenRuntimePlugin {
+	plugins := make([]EigenRuntimePlugin, 0, len(h.plugins))
+	for _, p := range h.plugins {
+		plugins = append(plugins, p)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name() < plugins[j].Name() })
+	return plugins
+}
+
+// Commands returns the verb-first commands for every registered plugin,
+// e.g. "run <plugin>", followed by the "plugins" command. A verb is only
+// returned if at least one plugin provides commands for it. It fails if a
+// plugin returns two commands with the same name for a verb.
+//
+// A verb given a spec file or artifact reference instead of a plugin name,
+// e.g. "run ./spec.yaml", routes it to the plugin that claims the spec's
+// kind.
+func (h *Host) Commands() ([]*cli.Command, error) {
+	var commands []*cli.Command
+	for _, v := range verbs {
+		cmd := &cli.Command{
+			Name:      v.name,
+			Usage:     v.usage,
+			ArgsUsage: "<plugin> | <spec file or reference>",
+			Flags:     v.flags,
+			Before:    h.withHost,
+			Action:    h.route(v),
+		}
+		for _, p := range h.Plugins() {
+			mounted, err := mount(p, v.name, v.commands(p))
+			if err != nil {
+				return nil, err
+			}
+			if mounted != nil {
+				cmd.Subcommands = append(cmd.Subcommands, mounted)
+			}
+		}
+		if len(cmd.Subcommands) > 0 {
+			commands = append(commands, cmd)
+		}
+	}
+
+	return append(commands, h.pluginsCommand()), nil
+}
+
+// mount returns the command for plugin p under a verb. A single command is
+// mounted as the plugin itself, so "run <plugin>" invokes it directly;
+// several commands become subcommands of the plugin.
+func mount(p EigenRuntimePlugin, verbName string, commands []*cli.Command) (*cli.Command, error) {
+	switch len(commands) {
+	case 0:
+		return nil, nil
+	case 1:
+		cmd := *commands[0]
+		cmd.Name = p.Name()
+		cmd.Aliases = nil
+		return &cmd, nil
+	}
+
+	seen := make(map[string]bool, len(commands))
+	for _, cmd := range commands {
+		for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if seen[name] {
+				return nil, fmt.Errorf("plugin %s: duplicate %s command %q", p.Name(), verbName, name)
+			}
+			seen[name] = true
+		}
+	}
+
+	return &cli.Command{
+		Name:        p.Name(),
+		Usage:       fmt.Sprintf("%s commands of the %s plugin", verbName, p.Name()),
+		Subcommands: commands,
+	}, nil
+}
+
+// route returns the action of a verb, which resolves the spec named by its
+// first argument and runs the verb's command of the plugin claiming it.
+func (h *Host) route(v verb) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if !c.Args().Present() {
+			return cli.ShowSubcommandHelp(c)
+		}
+
+		resolved, err := h.resolve(c, c.Args().First())
+		if err != nil {
+			return err
+		}
+		p, err := h.PluginFor(resolved.spec)
+		if err != nil {
+			return err
+		}
+
+		commands := v.commands(p)
+		switch len(commands) {
+		case 0:
+			return fmt.Errorf("plugin %s has no %s command", p.Name(), v.name)
+		case 1:
+		default:
+			return fmt.Errorf("plugin %s has several %s commands; run \"%s %s <command>\"", p.Name(), v.name, v.name, p.Name())
+		}
+
+		h.opts.Logger.Debug("routing spec to plugin", "kind", resolved.spec.Kind, "plugin", p.Name())
+
+		// Reuse the resolved spec rather than pulling it again.
+		c.Context = context.WithValue(c.Context, resolvedKey{}, resolved)
+		target := commands[0]
+		sub := cli.NewContext(c.App, nil, c)
+		sub.Command = target
+		return target.Run(sub, append([]string{target.Name}, c.Args().Slice()...)...)
+	}
+}
+
+func (h *Host) pluginsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "plugins",
+		Usage: "Manage plugins",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List installed plugins",
+				Action: func(c *cli.Context) error {
+					w := tabwriter.NewWriter(c.App.Writer, 0, 0, 2, ' ', 0)
+					fmt.Fprintln(w, "NAME\tVERSION\tKINDS")
+					for _, p := range h.Plugins() {
+						fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name(), p.Version(), formatSpecTypes(h.caps[p.Name()].Specs))
+					}
+					return w.Flush()
+				},
+			},
+		},
+	}
+}
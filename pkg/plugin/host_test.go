@@ -0,0 +1,182 @@
+package plugin
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// recordingPlugin is a plugin whose commands record their invocation.
+type recordingPlugin struct {
+	name     string
+	invoked  []string
+	commands map[string][]string
+}
+
+func (p *recordingPlugin) Name() string    { return p.name }
+func (p *recordingPlugin) Version() string { return "0.1.0" }
+
+func (p *recordingPlugin) command(verb string) []*cli.Command {
+	var cmds []*cli.Command
+	for _, name := range p.commands[verb] {
+		name := name
+		cmds = append(cmds, &cli.Command{
+			Name: name,
+			Action: func(c *cli.Context) error {
+				p.invoked = append(p.invoked, verb+" "+name+" "+strings.Join(c.Args().Slice(), " "))
+				return nil
+			},
+		})
+	}
+	return cmds
+}
+
+func (p *recordingPlugin) DescribeCommands() []*cli.Command { return p.command("describe") }
+func (p *recordingPlugin) GetCommands() []*cli.Command      { return p.command("get") }
+func (p *recordingPlugin) RunCommands() []*cli.Command      { return p.command("run") }
+func (p *recordingPlugin) RemoveCommands() []*cli.Command   { return p.command("remove") }
+
+func newTestApp(t *testing.T, h *Host) (*cli.App, *bytes.Buffer) {
+	t.Helper()
+
+	commands, err := h.Commands()
+	if err != nil {
+		t.Fatalf("Failed to build commands: %v", err)
+	}
+
+	var out bytes.Buffer
+	app := &cli.App{Name: "eigenruntime", Commands: commands, Writer: &out, ErrWriter: &out}
+	return app, &out
+}
+
+func TestHostCommands(t *testing.T) {
+	avs := &recordingPlugin{name: "avs", commands: map[string][]string{
+		"run":    {"start"},
+		"get":    {"status", "config"},
+		"remove": {"stop"},
+	}}
+
+	h := NewHost(HostOptions{})
+	if err := h.Register(avs, &TestPlugin{}); err != nil {
+		t.Fatalf("Failed to register plugins: %v", err)
+	}
+
+	app, out := newTestApp(t, h)
+
+	for _, args := range [][]string{
+		{"eigenruntime", "run", "avs", "my-runtime"},
+		{"eigenruntime", "get", "avs", "config"},
+		{"eigenruntime", "remove", "avs"},
+	} {
+		if err := app.Run(args); err != nil {
+			t.Fatalf("Failed to run %v: %v", args, err)
+		}
+	}
+
+	expected := []string{"run start my-runtime", "get config ", "remove stop "}
+	if strings.Join(avs.invoked, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected invocations %q, got %q", expected, avs.invoked)
+	}
+
+	var verbs []string
+	for _, cmd := range app.Commands {
+		if cmd.Name == "help" {
+			continue
+		}
+		verbs = append(verbs, cmd.Name)
+	}
+	if strings.Join(verbs, " ") != "describe get run remove register plugins" {
+		t.Errorf("Unexpected top-level commands: %v", verbs)
+	}
+
+	out.Reset()
+	if err := app.Run([]string{"eigenruntime", "plugins", "list"}); err != nil {
+		t.Fatalf("Failed to list plugins: %v", err)
+	}
+	expectedList := "NAME         VERSION  KINDS\navs          0.1.0    -\ntest-plugin  1.0.0    -\n"
+	if out.String() != expectedList {
+		t.Errorf("Expected plugin list:\n%s\ngot:\n%s", expectedList, out.String())
+	}
+}
+
+func TestHostDuplicates(t *testing.T) {
+	h := NewHost(HostOptions{})
+	if err := h.Register(&TestPlugin{}); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+
+	if err := h.Register(&TestPlugin{}); !errors.Is(err, ErrDuplicatePlugin) {
+		t.Errorf("Expected ErrDuplicatePlugin, got %v", err)
+	}
+
+	other := &recordingPlugin{name: "other"}
+	if err := h.Register(other, &recordingPlugin{name: "other"}); !errors.Is(err, ErrDuplicatePlugin) {
+		t.Errorf("Expected ErrDuplicatePlugin within one call, got %v", err)
+	}
+	if _, ok := h.Lookup("other"); ok {
+		t.Error("Expected a failed Register not to register any plugin")
+	}
+
+	clash := &recordingPlugin{name: "clash", commands: map[string][]string{"get": {"status", "status"}}}
+	if err := h.Register(clash); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	if _, err := h.Commands(); err == nil || !strings.Contains(err.Error(), `duplicate get command "status"`) {
+		t.Errorf("Expected duplicate command error, got %v", err)
+	}
+}
+
+// lifecyclePlugin opts into the status and upgrade verbs.
+type lifecyclePlugin struct {
+	*recordingPlugin
+}
+
+func (p lifecyclePlugin) StatusCommands() []*cli.Command  { return p.command("status") }
+func (p lifecyclePlugin) UpgradeCommands() []*cli.Command { return p.command("upgrade") }
+
+func TestHostLifecycleVerbs(t *testing.T) {
+	var _ Registerer = (*TestPlugin)(nil)
+	var _ interface {
+		EigenRuntimePlugin
+		Registerer
+		Deregisterer
+		StatusReporter
+		LogStreamer
+		Upgrader
+	} = (*ExternalPlugin)(nil)
+
+	p := lifecyclePlugin{&recordingPlugin{name: "avs", commands: map[string][]string{
+		"status":  {"show"},
+		"upgrade": {"apply"},
+		"logs":    {"tail"}, // ignored: LogStreamer is not implemented
+	}}}
+
+	h := NewHost(HostOptions{})
+	if err := h.Register(p); err != nil {
+		t.Fatalf("Failed to register plugin: %v", err)
+	}
+	app, _ := newTestApp(t, h)
+
+	for _, args := range [][]string{
+		{"eigenruntime", "status", "avs"},
+		{"eigenruntime", "upgrade", "avs", "v2"},
+	} {
+		if err := app.Run(args); err != nil {
+			t.Fatalf("Failed to run %v: %v", args, err)
+		}
+	}
+
+	expected := []string{"status show ", "upgrade apply v2"}
+	if strings.Join(p.invoked, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected invocations %q, got %q", expected, p.invoked)
+	}
+
+	for _, cmd := range app.Commands {
+		if cmd.Name == "logs" {
+			t.Error("Expected no logs verb for a plugin without LogStreamer")
+		}
+	}
+}
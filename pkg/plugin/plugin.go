@@ -4,6 +4,9 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// EigenRuntimePlugin is implemented by every plugin. Lifecycle verbs beyond
+// describe, get, run and remove are opted into by also implementing
+// Registerer, Deregisterer, StatusReporter, LogStreamer or Upgrader.
 type EigenRuntimePlugin interface {
 	Name() string
 	Version() string
@@ -12,3 +15,33 @@ type EigenRuntimePlugin interface {
 	RunCommands() []*cli.Command
 	RemoveCommands() []*cli.Command
 }
+
+// Registerer is implemented by plugins that register runtimes, e.g. with an
+// AVS contract, mounted as "eigenruntime register <plugin>".
+type Registerer interface {
+	RegistrationCommands() []*cli.Command
+}
+
+// Deregisterer is implemented by plugins that deregister runtimes, mounted
+// as "eigenruntime deregister <plugin>".
+type Deregisterer interface {
+	DeregistrationCommands() []*cli.Command
+}
+
+// StatusReporter is implemented by plugins that report the status of a
+// running runtime, mounted as "eigenruntime status <plugin>".
+type StatusReporter interface {
+	StatusCommands() []*cli.Command
+}
+
+// LogStreamer is implemented by plugins that show the logs of a runtime,
+// mounted as "eigenruntime logs <plugin>".
+type LogStreamer interface {
+	LogsCommands() []*cli.Command
+}
+
+// Upgrader is implemented by plugins that upgrade a running runtime to a
+// new spec, mounted as "eigenruntime upgrade <plugin>".
+type Upgrader interface {
+	UpgradeCommands() []*cli.Command
+}
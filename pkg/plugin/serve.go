@@ -0,0 +1,177 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/client"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/credentials"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/output"
+	"github.com/Layr-Labs/eigenruntime-go/pkg/spec"
+	"github.com/urfave/cli/v2"
+)
+
+// JSON-RPC error codes returned by Serve.
+const (
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// Serve runs p as an external plugin executable. When started by a host for
+// the handshake it describes p over stdin and stdout; otherwise it runs the
+// command selected by args, where args[0] is the program name followed by
+// the verb and the command path, e.g. "run start".
+//
+// Commands run with the registry settings, admission policy, output format
+// and resolved runtime forwarded by the host (see PlainHTTPEnv), and with
+// the credentials in the Docker config file.
+//
+// An external plugin's main function is typically:
+//
+//	func main() {
+//		if err := plugin.Serve(&myPlugin{}, os.Args); err != nil {
+//			fmt.Fprintln(os.Stderr, err)
+//			os.Exit(1)
+//		}
+//	}
+func Serve(p EigenRuntimePlugin, args []string) error {
+	if os.Getenv(ProtocolEnv) != "" {
+		return serveHandshake(p, os.Stdin, os.Stdout)
+	}
+
+	opts, err := hostOptionsFromEnv()
+	if err != nil {
+		return err
+	}
+	h := NewHost(opts)
+	h.pinned = resolvedSpec{reference: os.Getenv(ReferenceEnv), digest: os.Getenv(DigestEnv)}
+
+	app := &cli.App{
+		Name:    filepath.Base(args[0]),
+		Version: p.Version(),
+	}
+	for _, v := range verbs {
+		if commands := v.commands(p); len(commands) > 0 {
+			app.Commands = append(app.Commands, &cli.Command{Name: v.name, Usage: v.usage, Before: h.withHost, Subcommands: commands})
+		}
+	}
+	return app.Run(args)
+}
+
+// hostOptionsFromEnv creates the options of a plugin's host from the
+// settings forwarded by the host that started it.
+func hostOptionsFromEnv() (HostOptions, error) {
+	opts := HostOptions{
+		RegistryConfig: os.Getenv(RegistryConfigEnv),
+		CacheDir:       os.Getenv(CacheDirEnv),
+	}
+	if v := os.Getenv(PlainHTTPEnv); v != "" {
+		plainHTTP, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s %q: %w", PlainHTTPEnv, v, err)
+		}
+		opts.PlainHTTP = plainHTTP
+	}
+
+	switch format := os.Getenv(OutputEnv); format {
+	case "":
+	case "text":
+		opts.Output, opts.OutputFormat = output.Text{}, format
+	default:
+		f, err := output.Parse(format)
+		if err != nil {
+			return opts, err
+		}
+		opts.Output, opts.OutputFormat = f, format
+	}
+
+	clientOpts := client.ClientOptions{
+		PlainHTTP:   opts.PlainHTTP,
+		CacheDir:    opts.CacheDir,
+		Credentials: credentials.Default(),
+	}
+	if opts.RegistryConfig != "" {
+		if err := client.LoadRegistryConfig(opts.RegistryConfig, &clientOpts); err != nil {
+			return opts, err
+		}
+	}
+	opts.Client = client.NewClient(clientOpts)
+
+	if path := os.Getenv(PolicyEnv); path != "" {
+		policy, err := spec.LoadAdmissionPolicy(path)
+		if err != nil {
+			return opts, err
+		}
+		opts.AdmissionPolicy = policy
+	}
+	return opts, nil
+}
+
+func serveHandshake(p EigenRuntimePlugin, r io.Reader, w io.Writer) error {
+	var req rpcRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return fmt.Errorf("failed to read handshake: %w", err)
+	}
+
+	resp := rpcResponse{JSONRPC: jsonRPCVersion, ID: req.ID}
+	result, rpcErr := handshake(p, req)
+	if rpcErr != nil {
+		resp.Error = rpcErr
+	} else {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		resp.Result = data
+	}
+
+	return json.NewEncoder(w).Encode(resp)
+}
+
+func handshake(p EigenRuntimePlugin, req rpcRequest) (*HandshakeResponse, *rpcError) {
+	if req.Method != handshakeMethod {
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: "unknown method " + strconv.Quote(req.Method)}
+	}
+
+	var params HandshakeRequest
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+	}
+	if params.ProtocolVersion != ProtocolVersion {
+		return nil, &rpcError{
+			Code:    rpcInvalidParams,
+			Message: fmt.Sprintf("unsupported protocol version %d, plugin speaks version %d", params.ProtocolVersion, ProtocolVersion),
+		}
+	}
+
+	resp := &HandshakeResponse{
+		ProtocolVersion: ProtocolVersion,
+		Name:            p.Name(),
+		Version:         p.Version(),
+		Capabilities:    capabilities(p),
+		Commands:        make(map[string][]CommandInfo),
+	}
+	for _, v := range verbs {
+		if commands := v.commands(p); len(commands) > 0 {
+			resp.Commands[v.name] = commandInfos(commands)
+		}
+	}
+	return resp, nil
+}
+
+func commandInfos(commands []*cli.Command) []CommandInfo {
+	infos := make([]CommandInfo, 0, len(commands))
+	for _, cmd := range commands {
+		infos = append(infos, CommandInfo{
+			Name:        cmd.Name,
+			Usage:       cmd.Usage,
+			Aliases:     cmd.Aliases,
+			Subcommands: commandInfos(cmd.Subcommands),
+		})
+	}
+	return infos
+}
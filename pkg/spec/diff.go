@@ -0,0 +1,274 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+// ChangeType describes how a field differs between two specs.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// Change is a single difference between two runtime specs. Path locates the
+// field, e.g. "version" or "spec.performer.env.API_KEY"; Old and New hold its
+// value before and after, and are nil when the field was added or removed.
+//
+// Breaking marks changes an operator cannot roll out without action: a
+// removed component, a newly required environment variable, or a different
+// apiVersion or kind.
+type Change struct {
+	Path     string     `json:"path"`
+	Type     ChangeType `json:"type"`
+	Old      any        `json:"old,omitempty"`
+	New      any        `json:"new,omitempty"`
+	Breaking bool       `json:"breaking"`
+}
+
+// ChangeSet is the ordered list of changes between two runtime specs.
+type ChangeSet []Change
+
+// Diff returns the changes that turn from into to, ordered by path. A nil
+// spec is treated as empty.
+func Diff(from, to *common.RuntimeSpec) ChangeSet {
+	if from == nil {
+		from = &common.RuntimeSpec{}
+	}
+	if to == nil {
+		to = &common.RuntimeSpec{}
+	}
+
+	var changes ChangeSet
+	changes = diffValue(changes, "apiVersion", from.APIVersion, to.APIVersion).markBreaking("apiVersion")
+	changes = diffValue(changes, "kind", from.Kind, to.Kind).markBreaking("kind")
+	changes = diffValue(changes, "name", from.Name, to.Name)
+	changes = diffValue(changes, "version", from.Version, to.Version)
+
+	for _, name := range unionKeys(from.Spec, to.Spec) {
+		path := "spec." + name
+		fromComponent, inFrom := from.Spec[name]
+		toComponent, inTo := to.Spec[name]
+
+		switch {
+		case !inFrom:
+			changes = append(changes, Change{Path: path, Type: ChangeAdded, New: toComponent,
+				Breaking: hasRequiredEnv(toComponent)})
+		case !inTo:
+			changes = append(changes, Change{Path: path, Type: ChangeRemoved, Old: fromComponent, Breaking: true})
+		default:
+			changes = diffComponent(changes, path, fromComponent, toComponent)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+func diffComponent(changes ChangeSet, path string, from, to common.Component) ChangeSet {
+	changes = diffValue(changes, path+".registry", from.Registry, to.Registry)
+	changes = diffValue(changes, path+".digest", from.Digest, to.Digest)
+
+	if !reflect.DeepEqual(from.Command, to.Command) {
+		changes = append(changes, change(path+".command", nilIfEmpty(from.Command), nilIfEmpty(to.Command)))
+	}
+
+	fromEnv := envByName(from.Env)
+	toEnv := envByName(to.Env)
+	for _, name := range unionKeys(fromEnv, toEnv) {
+		o, inFrom := fromEnv[name]
+		n, inTo := toEnv[name]
+		switch {
+		case !inFrom:
+			changes = append(changes, Change{Path: path + ".env." + name, Type: ChangeAdded, New: n,
+				Breaking: n.Required})
+		case !inTo:
+			changes = append(changes, Change{Path: path + ".env." + name, Type: ChangeRemoved, Old: o})
+		case o != n:
+			changes = append(changes, Change{Path: path + ".env." + name, Type: ChangeModified, Old: o, New: n,
+				Breaking: n.Required && !o.Required})
+		}
+	}
+
+	if fromTEE, toTEE := teeEnabled(from.Resources), teeEnabled(to.Resources); fromTEE != toTEE {
+		changes = append(changes, Change{Path: path + ".resources.teeEnabled", Type: ChangeModified, Old: fromTEE, New: toTEE})
+	}
+
+	return changes
+}
+
+func diffValue[T comparable](changes ChangeSet, path string, from, to T) ChangeSet {
+	if from == to {
+		return changes
+	}
+
+	var zero T
+	var o, n any = from, to
+	if from == zero {
+		o = nil
+	}
+	if to == zero {
+		n = nil
+	}
+	return append(changes, change(path, o, n))
+}
+
+func change(path string, from, to any) Change {
+	switch {
+	case from == nil:
+		return Change{Path: path, Type: ChangeAdded, New: to}
+	case to == nil:
+		return Change{Path: path, Type: ChangeRemoved, Old: from}
+	default:
+		return Change{Path: path, Type: ChangeModified, Old: from, New: to}
+	}
+}
+
+// markBreaking marks the change to path, if any, as breaking.
+func (cs ChangeSet) markBreaking(path string) ChangeSet {
+	for i := range cs {
+		if cs[i].Path == path {
+			cs[i].Breaking = true
+		}
+	}
+	return cs
+}
+
+func hasRequiredEnv(c common.Component) bool {
+	for _, e := range c.Env {
+		if e.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func nilIfEmpty(s []string) any {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func envByName(env []common.EnvVar) map[string]common.EnvVar {
+	byName := make(map[string]common.EnvVar, len(env))
+	for _, e := range env {
+		byName[e.Name] = e
+	}
+	return byName
+}
+
+func teeEnabled(r *common.Resources) bool {
+	return r != nil && r.TEEEnabled
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsBreaking reports whether any change in the set is breaking.
+func (cs ChangeSet) IsBreaking() bool {
+	for _, c := range cs {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Breaking returns the breaking changes in the set.
+func (cs ChangeSet) Breaking() ChangeSet {
+	var breaking ChangeSet
+	for _, c := range cs {
+		if c.Breaking {
+			breaking = append(breaking, c)
+		}
+	}
+	return breaking
+}
+
+// String renders the change set one change per line, in the style of a
+// unified diff, with breaking changes flagged.
+func (cs ChangeSet) String() string {
+	if len(cs) == 0 {
+		return "no changes\n"
+	}
+
+	var b strings.Builder
+	for _, c := range cs {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// ToJSON renders the change set as an indented JSON array.
+func (cs ChangeSet) ToJSON() ([]byte, error) {
+	if cs == nil {
+		cs = ChangeSet{}
+	}
+
+	data, err := json.MarshalIndent(cs, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal diff to JSON: %w", err)
+	}
+	return data, nil
+}
+
+func (c Change) String() string {
+	var line string
+	switch c.Type {
+	case ChangeAdded:
+		line = fmt.Sprintf("+ %s: %s", c.Path, formatValue(c.New))
+	case ChangeRemoved:
+		line = fmt.Sprintf("- %s: %s", c.Path, formatValue(c.Old))
+	default:
+		line = fmt.Sprintf("~ %s: %s -> %s", c.Path, formatValue(c.Old), formatValue(c.New))
+	}
+
+	if c.Breaking {
+		line += " (breaking)"
+	}
+	return line
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case common.Component:
+		return v.Registry + "@" + v.Digest
+	case common.EnvVar:
+		var attrs []string
+		if v.Type != "" {
+			attrs = append(attrs, v.Type)
+		}
+		if v.Required {
+			attrs = append(attrs, "required")
+		}
+		if len(attrs) == 0 {
+			return v.Name
+		}
+		return fmt.Sprintf("%s (%s)", v.Name, strings.Join(attrs, ", "))
+	case []string:
+		return strings.Join(v, " ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
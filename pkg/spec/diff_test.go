@@ -0,0 +1,172 @@
+package spec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+func TestDiff(t *testing.T) {
+	base := func() *common.RuntimeSpec {
+		return &common.RuntimeSpec{
+			APIVersion: "eigenruntime.io/v1alpha1",
+			Kind:       "Runtime",
+			Name:       "example",
+			Version:    "v1.0.0",
+			Spec: map[string]common.Component{
+				"performer": {
+					Registry: "ghcr.io/example/performer",
+					Digest:   "sha256:aaa",
+					Env:      []common.EnvVar{{Name: "RPC_URL"}},
+				},
+				"sidecar": {
+					Registry: "ghcr.io/example/sidecar",
+					Digest:   "sha256:bbb",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(s *common.RuntimeSpec)
+		expected []string
+		breaking bool
+	}{
+		{
+			name:   "no changes",
+			modify: func(s *common.RuntimeSpec) {},
+		},
+		{
+			name: "digest and version bump",
+			modify: func(s *common.RuntimeSpec) {
+				s.Version = "v1.1.0"
+				c := s.Spec["performer"]
+				c.Digest = "sha256:ccc"
+				s.Spec["performer"] = c
+			},
+			expected: []string{
+				"~ spec.performer.digest: sha256:aaa -> sha256:ccc",
+				"~ version: v1.0.0 -> v1.1.0",
+			},
+		},
+		{
+			name: "removed component",
+			modify: func(s *common.RuntimeSpec) {
+				delete(s.Spec, "sidecar")
+			},
+			expected: []string{"- spec.sidecar: ghcr.io/example/sidecar@sha256:bbb (breaking)"},
+			breaking: true,
+		},
+		{
+			name: "added component",
+			modify: func(s *common.RuntimeSpec) {
+				s.Spec["indexer"] = common.Component{Registry: "ghcr.io/example/indexer", Digest: "sha256:ddd"}
+			},
+			expected: []string{"+ spec.indexer: ghcr.io/example/indexer@sha256:ddd"},
+		},
+		{
+			name: "new required env",
+			modify: func(s *common.RuntimeSpec) {
+				c := s.Spec["performer"]
+				c.Env = append(c.Env, common.EnvVar{Name: "API_KEY", Type: "secret", Required: true})
+				s.Spec["performer"] = c
+			},
+			expected: []string{"+ spec.performer.env.API_KEY: API_KEY (secret, required) (breaking)"},
+			breaking: true,
+		},
+		{
+			name: "env made required",
+			modify: func(s *common.RuntimeSpec) {
+				c := s.Spec["performer"]
+				c.Env = []common.EnvVar{{Name: "RPC_URL", Required: true}}
+				s.Spec["performer"] = c
+			},
+			expected: []string{"~ spec.performer.env.RPC_URL: RPC_URL -> RPC_URL (required) (breaking)"},
+			breaking: true,
+		},
+		{
+			name: "removed optional env",
+			modify: func(s *common.RuntimeSpec) {
+				c := s.Spec["performer"]
+				c.Env = nil
+				s.Spec["performer"] = c
+			},
+			expected: []string{"- spec.performer.env.RPC_URL: RPC_URL"},
+		},
+		{
+			name: "TEE toggled",
+			modify: func(s *common.RuntimeSpec) {
+				c := s.Spec["performer"]
+				c.Resources = &common.Resources{TEEEnabled: true}
+				s.Spec["performer"] = c
+			},
+			expected: []string{"~ spec.performer.resources.teeEnabled: false -> true"},
+		},
+		{
+			name: "kind changed",
+			modify: func(s *common.RuntimeSpec) {
+				s.Kind = "Job"
+			},
+			expected: []string{"~ kind: Runtime -> Job (breaking)"},
+			breaking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modified := base()
+			tt.modify(modified)
+
+			changes := Diff(base(), modified)
+
+			var lines []string
+			for _, c := range changes {
+				lines = append(lines, c.String())
+			}
+			if strings.Join(lines, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("Expected changes:\n%s\ngot:\n%s", strings.Join(tt.expected, "\n"), strings.Join(lines, "\n"))
+			}
+
+			if changes.IsBreaking() != tt.breaking {
+				t.Errorf("Expected breaking %v, got %v", tt.breaking, changes.IsBreaking())
+			}
+			if tt.breaking && len(changes.Breaking()) == 0 {
+				t.Error("Expected Breaking to return the breaking changes")
+			}
+		})
+	}
+}
+
+func TestChangeSetRendering(t *testing.T) {
+	old := &common.RuntimeSpec{Version: "v1.0.0"}
+	changes := Diff(old, &common.RuntimeSpec{Version: "v2.0.0"})
+
+	if got := changes.String(); got != "~ version: v1.0.0 -> v2.0.0\n" {
+		t.Errorf("Unexpected human-readable diff: %q", got)
+	}
+	if got := Diff(old, old).String(); got != "no changes\n" {
+		t.Errorf("Unexpected empty diff: %q", got)
+	}
+
+	data, err := changes.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to render JSON: %v", err)
+	}
+
+	var decoded []map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	if len(decoded) != 1 || decoded[0]["path"] != "version" || decoded[0]["type"] != "modified" ||
+		decoded[0]["old"] != "v1.0.0" || decoded[0]["new"] != "v2.0.0" || decoded[0]["breaking"] != false {
+		t.Errorf("Unexpected JSON diff: %s", data)
+	}
+
+	empty, err := Diff(old, old).ToJSON()
+	if err != nil || string(empty) != "[]" {
+		t.Errorf("Expected empty JSON array, got %s (%v)", empty, err)
+	}
+}
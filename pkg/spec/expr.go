@@ -0,0 +1,521 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expr is a compiled admission rule expression. The language is described
+// on AdmissionRule.
+type expr interface {
+	eval(env map[string]any) (any, error)
+}
+
+// compileExpr parses source into an expression.
+func compileExpr(source string) (expr, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+
+	return e, nil
+}
+
+// evalBool evaluates e and requires a boolean or null result.
+func evalBool(e expr, env map[string]any) (bool, error) {
+	v, err := e.eval(env)
+	if err != nil {
+		return false, err
+	}
+	return truthy(v)
+}
+
+func truthy(v any) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	default:
+		return false, fmt.Errorf("expected a boolean, got %T", v)
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func lex(source string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(source); {
+		c := rune(source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(source) && source[j] != source[i] {
+				if source[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(source) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			text := source[i+1 : j]
+			if c == '"' {
+				unquoted, err := strconv.Unquote(source[i : j+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at offset %d: %w", i, err)
+				}
+				text = unquoted
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: i})
+			i = j + 1
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(source) && (unicode.IsDigit(rune(source[j])) || source[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: source[i:j], pos: i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(source) && (unicode.IsLetter(rune(source[j])) || unicode.IsDigit(rune(source[j])) || source[j] == '_' || source[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: source[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(source[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at offset %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(source)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) accept(kind tokenKind, text string) bool {
+	if tok := p.peek(); tok.kind == kind && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(tokenOp, text) {
+		tok := p.peek()
+		return fmt.Errorf("expected %q at offset %d", text, tok.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenOp, "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(tokenOp, "&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.accept(tokenOp, "!") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+var comparisonOps = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"startsWith": true, "endsWith": true, "contains": true, "matches": true, "in": true,
+}
+
+func (p *parser) parseComparison() (expr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if (tok.kind != tokenOp && tok.kind != tokenIdent) || !comparisonOps[tok.text] {
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &comparisonExpr{op: tok.text, left: left, right: right}
+	if tok.text == "matches" {
+		if lit, ok := right.(*literalExpr); ok {
+			pattern, ok := lit.value.(string)
+			if !ok {
+				return nil, fmt.Errorf("matches requires a string pattern at offset %d", tok.pos)
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern at offset %d: %w", tok.pos, err)
+			}
+			cmp.pattern = re
+		}
+	}
+	return cmp, nil
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return &literalExpr{value: tok.text}, nil
+	case tokenNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", tok.text, tok.pos)
+		}
+		return &literalExpr{value: n}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalExpr{value: true}, nil
+		case "false":
+			return &literalExpr{value: false}, nil
+		case "null":
+			return &literalExpr{value: nil}, nil
+		}
+		if p.accept(tokenOp, "(") {
+			return p.parseCall(tok)
+		}
+		return &pathExpr{segments: strings.Split(tok.text, ".")}, nil
+	case tokenOp:
+		switch tok.text {
+		case "(":
+			e, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		case "[":
+			var items []expr
+			for !p.accept(tokenOp, "]") {
+				if len(items) > 0 {
+					if err := p.expect(","); err != nil {
+						return nil, err
+					}
+				}
+				item, err := p.parsePrimary()
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			return &listExpr{items: items}, nil
+		}
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+}
+
+func (p *parser) parseCall(name token) (expr, error) {
+	if name.text != "len" {
+		return nil, fmt.Errorf("unknown function %q at offset %d", name.text, name.pos)
+	}
+
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &lenExpr{arg: arg}, nil
+}
+
+type literalExpr struct {
+	value any
+}
+
+func (e *literalExpr) eval(map[string]any) (any, error) {
+	return e.value, nil
+}
+
+type listExpr struct {
+	items []expr
+}
+
+func (e *listExpr) eval(env map[string]any) (any, error) {
+	values := make([]any, len(e.items))
+	for i, item := range e.items {
+		v, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+type pathExpr struct {
+	segments []string
+}
+
+func (e *pathExpr) eval(env map[string]any) (any, error) {
+	v, ok := env[e.segments[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %q", e.segments[0])
+	}
+	for _, segment := range e.segments[1:] {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		v = m[segment]
+	}
+	return v, nil
+}
+
+type lenExpr struct {
+	arg expr
+}
+
+func (e *lenExpr) eval(env map[string]any) (any, error) {
+	v, err := e.arg.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return float64(0), nil
+	case string:
+		return float64(len(v)), nil
+	case []any:
+		return float64(len(v)), nil
+	case map[string]any:
+		return float64(len(v)), nil
+	default:
+		return nil, fmt.Errorf("len: unsupported type %T", v)
+	}
+}
+
+type notExpr struct {
+	operand expr
+}
+
+func (e *notExpr) eval(env map[string]any) (any, error) {
+	b, err := evalBool(e.operand, env)
+	if err != nil {
+		return nil, err
+	}
+	return !b, nil
+}
+
+type logicalExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e *logicalExpr) eval(env map[string]any) (any, error) {
+	left, err := evalBool(e.left, env)
+	if err != nil {
+		return nil, err
+	}
+	if e.op == "&&" && !left {
+		return false, nil
+	}
+	if e.op == "||" && left {
+		return true, nil
+	}
+	return evalBool(e.right, env)
+}
+
+type comparisonExpr struct {
+	op          string
+	left, right expr
+	pattern     *regexp.Regexp
+}
+
+func (e *comparisonExpr) eval(env map[string]any) (any, error) {
+	left, err := e.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	case "in":
+		switch r := right.(type) {
+		case []any:
+			for _, item := range r {
+				if reflect.DeepEqual(left, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]any:
+			key, ok := left.(string)
+			if !ok {
+				return false, nil
+			}
+			_, found := r[key]
+			return found, nil
+		case nil:
+			return false, nil
+		default:
+			return nil, fmt.Errorf("in: unsupported type %T", right)
+		}
+	case "contains":
+		if list, ok := left.([]any); ok {
+			for _, item := range list {
+				if reflect.DeepEqual(item, right) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	}
+
+	if left == nil || right == nil {
+		return false, nil
+	}
+
+	if l, ok := left.(float64); ok {
+		r, ok := right.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s: cannot compare number with %T", e.op, right)
+		}
+		switch e.op {
+		case "<":
+			return l < r, nil
+		case "<=":
+			return l <= r, nil
+		case ">":
+			return l > r, nil
+		case ">=":
+			return l >= r, nil
+		}
+		return nil, fmt.Errorf("%s: unsupported for numbers", e.op)
+	}
+
+	l, lok := left.(string)
+	r, rok := right.(string)
+	if !lok || !rok {
+		return nil, fmt.Errorf("%s: expected strings, got %T and %T", e.op, left, right)
+	}
+
+	switch e.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "startsWith":
+		return strings.HasPrefix(l, r), nil
+	case "endsWith":
+		return strings.HasSuffix(l, r), nil
+	case "contains":
+		return strings.Contains(l, r), nil
+	case "matches":
+		re := e.pattern
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(r); err != nil {
+				return nil, fmt.Errorf("matches: %w", err)
+			}
+		}
+		return re.MatchString(l), nil
+	}
+
+	return nil, fmt.Errorf("unsupported operator %s", e.op)
+}
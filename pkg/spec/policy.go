@@ -0,0 +1,252 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Scopes an admission rule can be evaluated in.
+const (
+	// ScopeSpec evaluates the rule once, with the spec bound to "spec".
+	ScopeSpec = ""
+	// ScopeComponents evaluates the rule for each component, additionally
+	// binding "name" and "component".
+	ScopeComponents = "components"
+	// ScopeEnv evaluates the rule for each environment variable of each
+	// component, additionally binding "env".
+	ScopeEnv = "env"
+)
+
+// AdmissionRule is one organisational rule a spec must satisfy before it is
+// pushed or run. Rules written in Go set Check. Declarative rules set
+// Require, and optionally When, to boolean expressions over the JSON form of
+// the spec, such as:
+//
+//	component.registry startsWith "ghcr.io/our-org/"
+//	name != "performer" || component.resources.teeEnabled
+//	len(component.command) == 0 && !(env.name matches "^AWS_")
+//
+// Expressions support string, number, boolean, null and list literals,
+// dotted paths into the bound variables, the operators == != < <= > >=
+// startsWith endsWith contains matches in && || !, parentheses and len().
+// A path to a missing field evaluates to null, which counts as false.
+type AdmissionRule struct {
+	Name string `yaml:"name" json:"name"`
+	// Message describes the rule and is reported with each violation.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	// ForEach selects the scope the rule is evaluated in: ScopeSpec,
+	// ScopeComponents or ScopeEnv.
+	ForEach string `yaml:"forEach,omitempty" json:"forEach,omitempty"`
+	// When restricts the rule to the scopes for which it is true.
+	When string `yaml:"when,omitempty" json:"when,omitempty"`
+	// Require must be true in every scope the rule applies to.
+	Require string `yaml:"require,omitempty" json:"require,omitempty"`
+
+	// Check is a rule implemented in Go. It is used instead of the
+	// expressions when set.
+	Check func(spec *common.RuntimeSpec) []Violation `yaml:"-" json:"-"`
+
+	when, require expr
+}
+
+// AdmissionPolicy is a set of admission rules.
+type AdmissionPolicy struct {
+	Rules []AdmissionRule `yaml:"rules" json:"rules"`
+}
+
+// LoadAdmissionPolicy reads a YAML or JSON policy file.
+func LoadAdmissionPolicy(path string) (*AdmissionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	return ParseAdmissionPolicy(data)
+}
+
+// ParseAdmissionPolicy parses a YAML or JSON policy and compiles its rules.
+func ParseAdmissionPolicy(data []byte) (*AdmissionPolicy, error) {
+	var policy AdmissionPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	if err := policy.Compile(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Compile checks every rule and parses its expressions ahead of Evaluate.
+// It is called by ParseAdmissionPolicy; policies built in code may call it
+// to report invalid rules early.
+func (p *AdmissionPolicy) Compile() error {
+	for i := range p.Rules {
+		if err := p.Rules[i].compile(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rule *AdmissionRule) compile(index int) error {
+	if rule.Name == "" {
+		return fmt.Errorf("rule %d: name is required", index)
+	}
+	if rule.Check != nil || rule.require != nil {
+		return nil
+	}
+
+	switch rule.ForEach {
+	case ScopeSpec, ScopeComponents, ScopeEnv:
+	default:
+		return fmt.Errorf("rule %s: unknown forEach %q", rule.Name, rule.ForEach)
+	}
+
+	if rule.Require == "" {
+		return fmt.Errorf("rule %s: require or a Go check is required", rule.Name)
+	}
+
+	var err error
+	if rule.require, err = compileExpr(rule.Require); err != nil {
+		return fmt.Errorf("rule %s: invalid require expression: %w", rule.Name, err)
+	}
+	if rule.When != "" {
+		if rule.when, err = compileExpr(rule.When); err != nil {
+			return fmt.Errorf("rule %s: invalid when expression: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns every violation of the policy by spec. A rule whose
+// expressions fail to evaluate is reported as violated.
+func (p *AdmissionPolicy) Evaluate(spec *common.RuntimeSpec) ([]Violation, error) {
+	doc, err := specDocument(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	for i, rule := range p.Rules {
+		// Rules are compiled on a copy so that a shared policy is never
+		// modified by evaluation.
+		if err := rule.compile(i); err != nil {
+			return nil, err
+		}
+
+		if rule.Check != nil {
+			for _, v := range rule.Check(spec) {
+				if v.Rule == "" {
+					v.Rule = rule.Name
+				}
+				violations = append(violations, v)
+			}
+			continue
+		}
+
+		for _, scope := range scopes(rule.ForEach, doc) {
+			if v, ok := rule.evaluate(scope); !ok {
+				violations = append(violations, v)
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// Check evaluates the policy against spec and returns a *ValidationError
+// listing the violations, if any.
+func (p *AdmissionPolicy) Check(spec *common.RuntimeSpec) error {
+	violations, err := p.Evaluate(spec)
+	if err != nil {
+		return err
+	}
+	return validationError(violations)
+}
+
+type scope struct {
+	path string
+	env  map[string]any
+}
+
+func (r *AdmissionRule) evaluate(s scope) (Violation, bool) {
+	violation := Violation{Rule: r.Name, Path: s.path, Message: r.Message}
+	if violation.Message == "" {
+		violation.Message = "must satisfy " + r.Require
+	}
+
+	if r.when != nil {
+		applies, err := evalBool(r.when, s.env)
+		if err != nil {
+			violation.Message = fmt.Sprintf("failed to evaluate when: %v", err)
+			return violation, false
+		}
+		if !applies {
+			return Violation{}, true
+		}
+	}
+
+	ok, err := evalBool(r.require, s.env)
+	if err != nil {
+		violation.Message = fmt.Sprintf("failed to evaluate require: %v", err)
+		return violation, false
+	}
+	return violation, ok
+}
+
+// scopes returns the variable bindings a rule is evaluated with.
+func scopes(forEach string, doc map[string]any) []scope {
+	if forEach == ScopeSpec {
+		return []scope{{env: map[string]any{"spec": doc}}}
+	}
+
+	components, _ := doc["spec"].(map[string]any)
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []scope
+	for _, name := range names {
+		component := components[name]
+		path := "spec." + name
+		if forEach == ScopeComponents {
+			result = append(result, scope{path: path, env: map[string]any{
+				"spec": doc, "name": name, "component": component,
+			}})
+			continue
+		}
+
+		c, _ := component.(map[string]any)
+		envs, _ := c["env"].([]any)
+		for _, env := range envs {
+			e, _ := env.(map[string]any)
+			envName, _ := e["name"].(string)
+			result = append(result, scope{path: path + ".env." + envName, env: map[string]any{
+				"spec": doc, "name": name, "component": component, "env": env,
+			}})
+		}
+	}
+	return result
+}
+
+// specDocument converts spec to the plain maps and lists expressions work
+// on, using the same field names as the JSON form of the spec.
+func specDocument(spec *common.RuntimeSpec) (map[string]any, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert spec: %w", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert spec: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,217 @@
+package spec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+const testPolicy = `
+rules:
+  - name: trusted-registries
+    message: registry must be under ghcr.io/our-org
+    forEach: components
+    require: component.registry startsWith "ghcr.io/our-org/"
+  - name: performer-tee
+    message: performer components must have TEE enabled
+    forEach: components
+    when: name == "performer"
+    require: component.resources.teeEnabled
+  - name: no-command-overrides
+    message: command overrides are not allowed
+    forEach: components
+    require: len(component.command) == 0
+  - name: typed-secrets
+    forEach: env
+    when: env.name matches "(?i)(key|token|secret)$"
+    require: env.type == "secret"
+  - name: runtime-kind
+    require: spec.kind in ["Runtime", "Job"]
+`
+
+func TestAdmissionPolicy(t *testing.T) {
+	policy, err := ParseAdmissionPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Failed to parse policy: %v", err)
+	}
+
+	compliant := func() *common.RuntimeSpec {
+		return &common.RuntimeSpec{
+			APIVersion: "eigenruntime.io/v1alpha1",
+			Kind:       "Runtime",
+			Name:       "example",
+			Version:    "1.0.0",
+			Spec: map[string]common.Component{
+				"performer": {
+					Registry:  "ghcr.io/our-org/performer",
+					Digest:    "sha256:aaa",
+					Env:       []common.EnvVar{{Name: "API_KEY", Type: "secret"}},
+					Resources: &common.Resources{TEEEnabled: true},
+				},
+				"sidecar": {
+					Registry: "ghcr.io/our-org/sidecar",
+					Digest:   "sha256:bbb",
+				},
+			},
+		}
+	}
+
+	if err := policy.Check(compliant()); err != nil {
+		t.Fatalf("Expected compliant spec to pass: %v", err)
+	}
+
+	s := compliant()
+	s.Kind = "Daemon"
+	s.Spec["performer"] = common.Component{
+		Registry: "docker.io/someone/performer",
+		Digest:   "sha256:aaa",
+		Command:  []string{"/bin/sh"},
+		Env:      []common.EnvVar{{Name: "API_KEY"}, {Name: "RPC_URL"}},
+	}
+
+	err = policy.Check(s)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	expected := []Violation{
+		{Rule: "trusted-registries", Path: "spec.performer", Message: "registry must be under ghcr.io/our-org"},
+		{Rule: "performer-tee", Path: "spec.performer", Message: "performer components must have TEE enabled"},
+		{Rule: "no-command-overrides", Path: "spec.performer", Message: "command overrides are not allowed"},
+		{Rule: "typed-secrets", Path: "spec.performer.env.API_KEY", Message: `must satisfy env.type == "secret"`},
+		{Rule: "runtime-kind", Message: `must satisfy spec.kind in ["Runtime", "Job"]`},
+	}
+	if len(validationErr.Violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %v", len(expected), validationErr.Violations)
+	}
+	for i := range expected {
+		if validationErr.Violations[i] != expected[i] {
+			t.Errorf("Violation %d: expected %+v, got %+v", i, expected[i], validationErr.Violations[i])
+		}
+	}
+}
+
+func TestAdmissionPolicyGoRule(t *testing.T) {
+	policy := &AdmissionPolicy{Rules: []AdmissionRule{{
+		Name: "max-components",
+		Check: func(s *common.RuntimeSpec) []Violation {
+			if len(s.Spec) > 1 {
+				return []Violation{{Path: "spec", Message: "at most one component is allowed"}}
+			}
+			return nil
+		},
+	}}}
+
+	s := &common.RuntimeSpec{Spec: map[string]common.Component{"a": {}, "b": {}}}
+	err := policy.Check(s)
+	if err == nil || err.Error() != "invalid spec: spec: at most one component is allowed (max-components)" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestParseAdmissionPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		errMsg string
+	}{
+		{"missing name", "rules:\n  - require: 'true'\n", "name is required"},
+		{"missing require", "rules:\n  - name: r\n", "require or a Go check is required"},
+		{"unknown scope", "rules:\n  - name: r\n    forEach: layers\n    require: 'true'\n", "unknown forEach"},
+		{"syntax error", "rules:\n  - name: r\n    require: 'spec.kind =='\n", "invalid require expression"},
+		{"unknown function", "rules:\n  - name: r\n    require: 'size(spec.spec) > 1'\n", "unknown function"},
+		{"bad pattern", "rules:\n  - name: r\n    require: 'spec.kind matches \"[\"'\n", "invalid pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAdmissionPolicy([]byte(tt.policy))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestExpressions(t *testing.T) {
+	env := map[string]any{
+		"spec": map[string]any{
+			"name":    "example",
+			"count":   float64(3),
+			"tags":    []any{"a", "b"},
+			"labels":  map[string]any{"team": "core"},
+			"enabled": true,
+		},
+	}
+
+	tests := []struct {
+		expr     string
+		expected bool
+		wantErr  bool
+	}{
+		{expr: `spec.name == "example"`, expected: true},
+		{expr: `spec.name != 'example'`, expected: false},
+		{expr: `spec.count >= 3 && spec.count < 4`, expected: true},
+		{expr: `spec.enabled || spec.missing`, expected: true},
+		{expr: `!spec.missing`, expected: true},
+		{expr: `spec.missing == null`, expected: true},
+		{expr: `spec.name endsWith "ple" && spec.name contains "xam"`, expected: true},
+		{expr: `spec.tags contains "b"`, expected: true},
+		{expr: `"team" in spec.labels`, expected: true},
+		{expr: `len(spec.tags) == 2 && len(spec.name) == 7`, expected: true},
+		{expr: `!(spec.name matches "^ex") || false`, expected: false},
+		{expr: `spec.count > "3"`, wantErr: true},
+		{expr: `spec.name`, wantErr: true},
+		{expr: `unknown.field`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := compileExpr(tt.expr)
+			if err != nil {
+				t.Fatalf("Failed to compile: %v", err)
+			}
+			got, err := evalBool(e, env)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an evaluation error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to evaluate: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateRuntimeSpec(t *testing.T) {
+	err := ValidateRuntimeSpec(&common.RuntimeSpec{
+		APIVersion: "eigenruntime.io/v1alpha1",
+		Name:       "example",
+		Version:    "latest",
+		Spec: map[string]common.Component{
+			"performer": {Registry: "ghcr.io/example/performer", Env: []common.EnvVar{{}}},
+		},
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+
+	var paths []string
+	for _, v := range validationErr.Violations {
+		paths = append(paths, v.Path)
+	}
+	expected := "kind version spec.performer.digest spec.performer.env[0].name"
+	if strings.Join(paths, " ") != expected {
+		t.Errorf("Expected violations at %s, got %v", expected, validationErr.Violations)
+	}
+}
@@ -0,0 +1,152 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/opencontainers/go-digest"
+)
+
+// PlaceholderDigest is the digest Scaffold gives components whose image is
+// not pinned to a digest. It passes validation but refers to no image, so
+// CheckPinned rejects it and artifact.Build refuses to package it.
+const PlaceholderDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+
+// DefaultScaffoldVersion is the version Scaffold uses when none is given.
+const DefaultScaffoldVersion = "0.1.0"
+
+// ScaffoldOptions describes the spec generated by Scaffold.
+type ScaffoldOptions struct {
+	// Name is the name of the runtime. It is required.
+	Name string
+	// Version defaults to DefaultScaffoldVersion.
+	Version string
+	// Kind defaults to common.DefaultKind.
+	Kind string
+	// Components lists the component names, in any order. It defaults to a
+	// single "performer" component.
+	Components []string
+	// Images maps component names to the image each one runs, either
+	// digest-pinned ("ghcr.io/org/app@sha256:...") or by tag
+	// ("ghcr.io/org/app:v1"). Components without an image get a placeholder
+	// registry under registry.example.com.
+	Images map[string]string
+	// TEE enables a TEE for every component.
+	TEE bool
+	// Resolve, when set, looks up the current digest of each image given by
+	// tag. Otherwise, and for components without an image, the digest is
+	// PlaceholderDigest.
+	Resolve func(ctx context.Context, reference string) (string, error)
+}
+
+// Scaffold generates a starter spec from opts. The result always passes
+// ValidateRuntimeSpec.
+func Scaffold(ctx context.Context, opts ScaffoldOptions) (*common.RuntimeSpec, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("a runtime name is required")
+	}
+	if opts.Version == "" {
+		opts.Version = DefaultScaffoldVersion
+	}
+	if opts.Kind == "" {
+		opts.Kind = common.DefaultKind
+	}
+	if len(opts.Components) == 0 {
+		opts.Components = []string{"performer"}
+	}
+
+	runtimeSpec := &common.RuntimeSpec{
+		APIVersion: common.DefaultAPIVersion,
+		Kind:       opts.Kind,
+		Name:       opts.Name,
+		Version:    opts.Version,
+		Spec:       make(map[string]common.Component, len(opts.Components)),
+	}
+
+	for _, name := range opts.Components {
+		if name == "" {
+			return nil, fmt.Errorf("component names cannot be empty")
+		}
+		if _, ok := runtimeSpec.Spec[name]; ok {
+			return nil, fmt.Errorf("duplicate component %q", name)
+		}
+
+		component := common.Component{
+			Registry:  "registry.example.com/" + opts.Name + "/" + name,
+			Digest:    PlaceholderDigest,
+			Resources: &common.Resources{TEEEnabled: opts.TEE},
+		}
+		if image, ok := opts.Images[name]; ok {
+			registry, d, err := pinImage(ctx, image, opts.Resolve)
+			if err != nil {
+				return nil, fmt.Errorf("component %s: %w", name, err)
+			}
+			component.Registry, component.Digest = registry, d
+		}
+		runtimeSpec.Spec[name] = component
+	}
+
+	for name := range opts.Images {
+		if _, ok := runtimeSpec.Spec[name]; !ok {
+			return nil, fmt.Errorf("image given for unknown component %q", name)
+		}
+	}
+
+	if err := ValidateRuntimeSpec(runtimeSpec); err != nil {
+		return nil, err
+	}
+	return runtimeSpec, nil
+}
+
+// pinImage splits image into its repository and digest, resolving a tag
+// with resolve when it is set.
+func pinImage(ctx context.Context, image string, resolve func(context.Context, string) (string, error)) (string, string, error) {
+	if repository, d, ok := strings.Cut(image, "@"); ok {
+		if _, err := digest.Parse(d); err != nil {
+			return "", "", fmt.Errorf("invalid digest in image %q: %w", image, err)
+		}
+		return repository, d, nil
+	}
+
+	i := strings.LastIndex(image, ":")
+	if i <= 0 || i < strings.LastIndex(image, "/") {
+		return "", "", fmt.Errorf("image %q has neither a tag nor a digest", image)
+	}
+	repository := image[:i]
+	if resolve == nil {
+		return repository, PlaceholderDigest, nil
+	}
+
+	resolved, err := resolve(ctx, image)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := digest.Parse(resolved); err != nil {
+		return "", "", fmt.Errorf("invalid digest %q resolved for %s: %w", resolved, image, err)
+	}
+	return repository, resolved, nil
+}
+
+// CheckPinned returns a *ValidationError listing the components whose
+// digest is still PlaceholderDigest, if any.
+func CheckPinned(runtimeSpec *common.RuntimeSpec) error {
+	names := make([]string, 0, len(runtimeSpec.Spec))
+	for name := range runtimeSpec.Spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var violations []Violation
+	for _, name := range names {
+		if runtimeSpec.Spec[name].Digest == PlaceholderDigest {
+			violations = append(violations, Violation{
+				Path:    "spec." + name + ".digest",
+				Message: "placeholder digest does not refer to an image",
+			})
+		}
+	}
+	return validationError(violations)
+}
@@ -0,0 +1,140 @@
+package spec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+const resolvedDigest = "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+func TestScaffold(t *testing.T) {
+	resolve := func(ctx context.Context, reference string) (string, error) {
+		if reference != "ghcr.io/example/performer:v1" {
+			return "", errors.New("not found")
+		}
+		return resolvedDigest, nil
+	}
+
+	tests := []struct {
+		name     string
+		opts     ScaffoldOptions
+		expected map[string]common.Component
+		wantErr  bool
+	}{
+		{
+			name: "defaults",
+			opts: ScaffoldOptions{Name: "my-avs"},
+			expected: map[string]common.Component{
+				"performer": {Registry: "registry.example.com/my-avs/performer", Digest: PlaceholderDigest, Resources: &common.Resources{}},
+			},
+		},
+		{
+			name: "tee components",
+			opts: ScaffoldOptions{Name: "my-avs", Components: []string{"aggregator", "executor"}, TEE: true},
+			expected: map[string]common.Component{
+				"aggregator": {Registry: "registry.example.com/my-avs/aggregator", Digest: PlaceholderDigest, Resources: &common.Resources{TEEEnabled: true}},
+				"executor":   {Registry: "registry.example.com/my-avs/executor", Digest: PlaceholderDigest, Resources: &common.Resources{TEEEnabled: true}},
+			},
+		},
+		{
+			name: "resolved tag",
+			opts: ScaffoldOptions{Name: "my-avs", Images: map[string]string{"performer": "ghcr.io/example/performer:v1"}, Resolve: resolve},
+			expected: map[string]common.Component{
+				"performer": {Registry: "ghcr.io/example/performer", Digest: resolvedDigest, Resources: &common.Resources{}},
+			},
+		},
+		{
+			name: "unresolved tag",
+			opts: ScaffoldOptions{Name: "my-avs", Images: map[string]string{"performer": "localhost:5000/performer:v1"}},
+			expected: map[string]common.Component{
+				"performer": {Registry: "localhost:5000/performer", Digest: PlaceholderDigest, Resources: &common.Resources{}},
+			},
+		},
+		{
+			name: "pinned image",
+			opts: ScaffoldOptions{Name: "my-avs", Images: map[string]string{"performer": "ghcr.io/example/performer@" + resolvedDigest}, Resolve: resolve},
+			expected: map[string]common.Component{
+				"performer": {Registry: "ghcr.io/example/performer", Digest: resolvedDigest, Resources: &common.Resources{}},
+			},
+		},
+		{name: "missing name", opts: ScaffoldOptions{}, wantErr: true},
+		{name: "invalid version", opts: ScaffoldOptions{Name: "my-avs", Version: "latest"}, wantErr: true},
+		{name: "duplicate component", opts: ScaffoldOptions{Name: "my-avs", Components: []string{"a", "a"}}, wantErr: true},
+		{name: "unknown component image", opts: ScaffoldOptions{Name: "my-avs", Images: map[string]string{"other": "ghcr.io/example/other:v1"}}, wantErr: true},
+		{name: "image without tag", opts: ScaffoldOptions{Name: "my-avs", Images: map[string]string{"performer": "localhost:5000/performer"}}, wantErr: true},
+		{name: "resolve failure", opts: ScaffoldOptions{Name: "my-avs", Images: map[string]string{"performer": "ghcr.io/example/missing:v1"}, Resolve: resolve}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtimeSpec, err := Scaffold(context.Background(), tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %+v", runtimeSpec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to scaffold spec: %v", err)
+			}
+
+			if runtimeSpec.APIVersion != common.DefaultAPIVersion || runtimeSpec.Kind != common.DefaultKind || runtimeSpec.Version != DefaultScaffoldVersion {
+				t.Errorf("Unexpected header: %+v", runtimeSpec)
+			}
+			if len(runtimeSpec.Spec) != len(tt.expected) {
+				t.Fatalf("Expected %d components, got %d", len(tt.expected), len(runtimeSpec.Spec))
+			}
+			for name, expected := range tt.expected {
+				got, ok := runtimeSpec.Spec[name]
+				if !ok {
+					t.Fatalf("Expected component %s", name)
+				}
+				if got.Registry != expected.Registry || got.Digest != expected.Digest || *got.Resources != *expected.Resources {
+					t.Errorf("Expected %s to be %+v, got %+v", name, expected, got)
+				}
+			}
+
+			// The scaffold must survive a round trip through YAML.
+			data, err := ToYAML(runtimeSpec)
+			if err != nil {
+				t.Fatalf("Failed to marshal spec: %v", err)
+			}
+			parsed, err := ParseYAML(data)
+			if err != nil {
+				t.Fatalf("Failed to parse scaffolded spec: %v", err)
+			}
+			if err := ValidateRuntimeSpec(parsed); err != nil {
+				t.Errorf("Expected scaffolded spec to be valid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckPinned(t *testing.T) {
+	runtimeSpec, err := Scaffold(context.Background(), ScaffoldOptions{
+		Name:       "my-avs",
+		Components: []string{"aggregator", "performer"},
+		Images:     map[string]string{"performer": "ghcr.io/example/performer@" + resolvedDigest},
+	})
+	if err != nil {
+		t.Fatalf("Failed to scaffold spec: %v", err)
+	}
+
+	var validationErr *ValidationError
+	if err := CheckPinned(runtimeSpec); !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %v", err)
+	}
+	if len(validationErr.Violations) != 1 || validationErr.Violations[0].Path != "spec.aggregator.digest" {
+		t.Errorf("Expected only the aggregator digest to be reported, got %v", validationErr.Violations)
+	}
+
+	aggregator := runtimeSpec.Spec["aggregator"]
+	aggregator.Digest = resolvedDigest
+	runtimeSpec.Spec["aggregator"] = aggregator
+	if err := CheckPinned(runtimeSpec); err != nil {
+		t.Errorf("Expected a pinned spec to pass, got %v", err)
+	}
+}
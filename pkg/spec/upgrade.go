@@ -0,0 +1,171 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Masterminds/semver/v3"
+)
+
+// UpgradeError is returned by UpgradePolicy.Check when a candidate breaks
+// one or more rules.
+type UpgradeError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *UpgradeError) Error() string {
+	return "upgrade policy violated: " + joinViolations(e.Violations)
+}
+
+// UpgradeRule checks a candidate spec against the currently deployed one.
+// Changes is the diff from current to candidate.
+type UpgradeRule struct {
+	Name  string
+	Check func(current, candidate *common.RuntimeSpec, changes ChangeSet) []Violation
+}
+
+// UpgradePolicy is a set of rules every upgrade must satisfy.
+type UpgradePolicy struct {
+	Rules []UpgradeRule
+}
+
+// DefaultUpgradePolicy returns a policy with every built-in rule.
+func DefaultUpgradePolicy() *UpgradePolicy {
+	return &UpgradePolicy{Rules: []UpgradeRule{
+		RequireVersionIncrease,
+		RequireMajorForNewSecrets,
+		PreserveTEE,
+	}}
+}
+
+// Check returns an *UpgradeError listing every violation if candidate may
+// not replace current, and the changes between them either way.
+func (p *UpgradePolicy) Check(current, candidate *common.RuntimeSpec) (ChangeSet, error) {
+	if current == nil || candidate == nil {
+		return nil, fmt.Errorf("current and candidate specs are required")
+	}
+
+	changes := Diff(current, candidate)
+
+	var violations []Violation
+	for _, rule := range p.Rules {
+		for _, v := range rule.Check(current, candidate, changes) {
+			if v.Rule == "" {
+				v.Rule = rule.Name
+			}
+			violations = append(violations, v)
+		}
+	}
+
+	if len(violations) > 0 {
+		return changes, &UpgradeError{Violations: violations}
+	}
+
+	return changes, nil
+}
+
+// RequireVersionIncrease requires the candidate version to be a strictly
+// greater semantic version than the current one.
+var RequireVersionIncrease = UpgradeRule{
+	Name: "version-increase",
+	Check: func(current, candidate *common.RuntimeSpec, _ ChangeSet) []Violation {
+		currentVersion, candidateVersion, violation := parseVersions(current, candidate)
+		if violation != nil {
+			return []Violation{*violation}
+		}
+
+		if !candidateVersion.GreaterThan(currentVersion) {
+			return []Violation{{
+				Path:    "version",
+				Message: fmt.Sprintf("%s must be greater than %s", candidate.Version, current.Version),
+			}}
+		}
+		return nil
+	},
+}
+
+// RequireMajorForNewSecrets rejects new required secrets, whether added to
+// an existing component, introduced by a new component, or made required,
+// unless the major version is bumped.
+var RequireMajorForNewSecrets = UpgradeRule{
+	Name: "major-for-new-secrets",
+	Check: func(current, candidate *common.RuntimeSpec, changes ChangeSet) []Violation {
+		var paths []string
+		for _, c := range changes {
+			switch v := c.New.(type) {
+			case common.EnvVar:
+				old, _ := c.Old.(common.EnvVar)
+				if isRequiredSecret(v) && !isRequiredSecret(old) {
+					paths = append(paths, c.Path)
+				}
+			case common.Component:
+				if c.Type != ChangeAdded {
+					continue
+				}
+				for _, env := range v.Env {
+					if isRequiredSecret(env) {
+						paths = append(paths, c.Path+".env."+env.Name)
+					}
+				}
+			}
+		}
+		if len(paths) == 0 {
+			return nil
+		}
+
+		currentVersion, candidateVersion, violation := parseVersions(current, candidate)
+		if violation != nil {
+			return []Violation{*violation}
+		}
+		if candidateVersion.Major() > currentVersion.Major() {
+			return nil
+		}
+
+		violations := make([]Violation, len(paths))
+		for i, path := range paths {
+			violations[i] = Violation{
+				Path:    path,
+				Message: fmt.Sprintf("new required secret needs a major version bump from %s", current.Version),
+			}
+		}
+		return violations
+	},
+}
+
+// PreserveTEE rejects disabling TEE on a component that has it enabled.
+var PreserveTEE = UpgradeRule{
+	Name: "preserve-tee",
+	Check: func(current, candidate *common.RuntimeSpec, _ ChangeSet) []Violation {
+		var violations []Violation
+		for _, name := range unionKeys(current.Spec, candidate.Spec) {
+			old, inOld := current.Spec[name]
+			new, inNew := candidate.Spec[name]
+			if !inOld || !inNew || !teeEnabled(old.Resources) || teeEnabled(new.Resources) {
+				continue
+			}
+			violations = append(violations, Violation{
+				Path:    "spec." + name + ".resources.teeEnabled",
+				Message: "TEE cannot be disabled once enabled",
+			})
+		}
+		return violations
+	},
+}
+
+func isRequiredSecret(env common.EnvVar) bool {
+	return env.Required && env.Type == "secret"
+}
+
+func parseVersions(current, candidate *common.RuntimeSpec) (*semver.Version, *semver.Version, *Violation) {
+	currentVersion, err := semver.NewVersion(current.Version)
+	if err != nil {
+		return nil, nil, &Violation{Path: "version", Message: fmt.Sprintf("current version %q is not a semantic version", current.Version)}
+	}
+
+	candidateVersion, err := semver.NewVersion(candidate.Version)
+	if err != nil {
+		return nil, nil, &Violation{Path: "version", Message: fmt.Sprintf("version %q is not a semantic version", candidate.Version)}
+	}
+
+	return currentVersion, candidateVersion, nil
+}
@@ -0,0 +1,177 @@
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+)
+
+func TestUpgradePolicy(t *testing.T) {
+	current := func() *common.RuntimeSpec {
+		return &common.RuntimeSpec{
+			APIVersion: "eigenruntime.io/v1alpha1",
+			Kind:       "Runtime",
+			Name:       "example",
+			Version:    "v1.2.0",
+			Spec: map[string]common.Component{
+				"performer": {
+					Registry:  "ghcr.io/example/performer",
+					Digest:    "sha256:aaa",
+					Env:       []common.EnvVar{{Name: "RPC_URL", Required: true}},
+					Resources: &common.Resources{TEEEnabled: true},
+				},
+			},
+		}
+	}
+	secret := common.EnvVar{Name: "API_KEY", Type: "secret", Required: true}
+
+	tests := []struct {
+		name     string
+		modify   func(s *common.RuntimeSpec)
+		expected []string
+	}{
+		{
+			name:   "minor bump",
+			modify: func(s *common.RuntimeSpec) { s.Version = "v1.3.0" },
+		},
+		{
+			name:     "same version",
+			modify:   func(s *common.RuntimeSpec) {},
+			expected: []string{"version-increase"},
+		},
+		{
+			name:     "downgrade",
+			modify:   func(s *common.RuntimeSpec) { s.Version = "v1.1.9" },
+			expected: []string{"version-increase"},
+		},
+		{
+			name:     "invalid version",
+			modify:   func(s *common.RuntimeSpec) { s.Version = "latest" },
+			expected: []string{"version-increase"},
+		},
+		{
+			name: "new secret without major bump",
+			modify: func(s *common.RuntimeSpec) {
+				s.Version = "v1.3.0"
+				c := s.Spec["performer"]
+				c.Env = append(c.Env, secret)
+				s.Spec["performer"] = c
+			},
+			expected: []string{"major-for-new-secrets"},
+		},
+		{
+			name: "new component with secret without major bump",
+			modify: func(s *common.RuntimeSpec) {
+				s.Version = "v1.3.0"
+				s.Spec["sidecar"] = common.Component{Registry: "ghcr.io/example/sidecar", Digest: "sha256:bbb",
+					Env: []common.EnvVar{secret}}
+			},
+			expected: []string{"major-for-new-secrets"},
+		},
+		{
+			name: "new secret with major bump",
+			modify: func(s *common.RuntimeSpec) {
+				s.Version = "v2.0.0"
+				c := s.Spec["performer"]
+				c.Env = append(c.Env, secret)
+				s.Spec["performer"] = c
+			},
+		},
+		{
+			name: "optional secret",
+			modify: func(s *common.RuntimeSpec) {
+				s.Version = "v1.3.0"
+				c := s.Spec["performer"]
+				c.Env = append(c.Env, common.EnvVar{Name: "API_KEY", Type: "secret"})
+				s.Spec["performer"] = c
+			},
+		},
+		{
+			name: "TEE disabled",
+			modify: func(s *common.RuntimeSpec) {
+				s.Version = "v2.0.0"
+				c := s.Spec["performer"]
+				c.Resources = nil
+				s.Spec["performer"] = c
+			},
+			expected: []string{"preserve-tee"},
+		},
+		{
+			name: "several violations",
+			modify: func(s *common.RuntimeSpec) {
+				c := s.Spec["performer"]
+				c.Env = append(c.Env, secret)
+				c.Resources.TEEEnabled = false
+				s.Spec["performer"] = c
+			},
+			expected: []string{"version-increase", "major-for-new-secrets", "preserve-tee"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := current()
+			candidate.Spec["performer"] = copyComponent(candidate.Spec["performer"])
+			tt.modify(candidate)
+
+			_, err := DefaultUpgradePolicy().Check(current(), candidate)
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Errorf("Expected upgrade to be allowed, got %v", err)
+				}
+				return
+			}
+
+			var upgradeErr *UpgradeError
+			if !errors.As(err, &upgradeErr) {
+				t.Fatalf("Expected *UpgradeError, got %v", err)
+			}
+
+			var rules []string
+			for _, v := range upgradeErr.Violations {
+				rules = append(rules, v.Rule)
+			}
+			if len(rules) != len(tt.expected) {
+				t.Fatalf("Expected violations %v, got %v", tt.expected, upgradeErr.Violations)
+			}
+			for i := range rules {
+				if rules[i] != tt.expected[i] {
+					t.Errorf("Expected violations %v, got %v", tt.expected, upgradeErr.Violations)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradePolicyCustomRules(t *testing.T) {
+	noRename := UpgradeRule{
+		Name: "no-rename",
+		Check: func(current, candidate *common.RuntimeSpec, changes ChangeSet) []Violation {
+			for _, c := range changes {
+				if c.Path == "name" {
+					return []Violation{{Path: "name", Message: "runtime cannot be renamed"}}
+				}
+			}
+			return nil
+		},
+	}
+
+	policy := &UpgradePolicy{Rules: []UpgradeRule{noRename}}
+	changes, err := policy.Check(&common.RuntimeSpec{Name: "a"}, &common.RuntimeSpec{Name: "b"})
+	if err == nil || err.Error() != "upgrade policy violated: name: runtime cannot be renamed (no-rename)" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("Expected the changes to be returned, got %v", changes)
+	}
+}
+
+func copyComponent(c common.Component) common.Component {
+	c.Env = append([]common.EnvVar(nil), c.Env...)
+	if c.Resources != nil {
+		r := *c.Resources
+		c.Resources = &r
+	}
+	return c
+}
@@ -1,59 +1,81 @@
 package spec
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
 )
 
+// ValidateRuntimeSpec checks that spec is complete and well-formed. On
+// failure the error is a *ValidationError listing every problem found.
 func ValidateRuntimeSpec(spec *common.RuntimeSpec) error {
 	if spec == nil {
 		return fmt.Errorf("spec cannot be nil")
 	}
 
-	if spec.APIVersion == "" {
-		return fmt.Errorf("apiVersion is required")
+	var violations []Violation
+	required := func(path, value string) {
+		if value == "" {
+			violations = append(violations, Violation{Path: path, Message: "is required"})
+		}
 	}
 
-	if spec.Kind == "" {
-		return fmt.Errorf("kind is required")
-	}
+	required("apiVersion", spec.APIVersion)
+	required("kind", spec.Kind)
+	required("name", spec.Name)
+	required("version", spec.Version)
 
-	if spec.Name == "" {
-		return fmt.Errorf("name is required")
+	if spec.Version != "" {
+		if _, err := NormalizeVersion(spec.Version); err != nil {
+			violations = append(violations, Violation{Path: "version", Message: err.Error()})
+		}
 	}
 
-	if spec.Version == "" {
-		return fmt.Errorf("version is required")
+	if len(spec.Spec) == 0 {
+		violations = append(violations, Violation{Path: "spec", Message: "must contain at least one component"})
 	}
 
-	if spec.Spec == nil || len(spec.Spec) == 0 {
-		return fmt.Errorf("spec must contain at least one component")
+	names := make([]string, 0, len(spec.Spec))
+	for name := range spec.Spec {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	for name, component := range spec.Spec {
-		if err := ValidateComponent(name, &component); err != nil {
-			return fmt.Errorf("validation failed for component %s: %w", name, err)
+	for _, name := range names {
+		component := spec.Spec[name]
+		var validationErr *ValidationError
+		if err := ValidateComponent(name, &component); errors.As(err, &validationErr) {
+			violations = append(violations, validationErr.Violations...)
 		}
 	}
 
-	return nil
+	return validationError(violations)
 }
 
+// ValidateComponent checks a single component of a spec. On failure the
+// error is a *ValidationError with paths under "spec.<name>".
 func ValidateComponent(name string, component *common.Component) error {
+	path := "spec." + name
+
+	var violations []Violation
 	if component.Registry == "" {
-		return fmt.Errorf("registry is required")
+		violations = append(violations, Violation{Path: path + ".registry", Message: "is required"})
 	}
 
 	if component.Digest == "" {
-		return fmt.Errorf("digest is required")
+		violations = append(violations, Violation{Path: path + ".digest", Message: "is required"})
 	}
 
-	for _, env := range component.Env {
+	for i, env := range component.Env {
 		if env.Name == "" {
-			return fmt.Errorf("environment variable name cannot be empty")
+			violations = append(violations, Violation{
+				Path:    fmt.Sprintf("%s.env[%d].name", path, i),
+				Message: "environment variable name cannot be empty",
+			})
 		}
 	}
 
-	return nil
-}
\ No newline at end of file
+	return validationError(violations)
+}
@@ -0,0 +1,78 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/eigenruntime-go/pkg/common"
+	"github.com/Masterminds/semver/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// NormalizeVersion parses v as a semantic version and returns it in
+// canonical MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] form. A leading "v" and
+// a missing minor or patch number are accepted, so "v1.2" becomes "1.2.0".
+func NormalizeVersion(v string) (string, error) {
+	if v == "" {
+		return "", fmt.Errorf("version is empty")
+	}
+
+	parsed, err := semver.NewVersion(v)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a semantic version: %w", v, err)
+	}
+
+	return parsed.String(), nil
+}
+
+// VersionTag returns the registry tag suggested for version, e.g. "v1.2.0"
+// for "1.2". Build metadata is kept with "+" replaced by "_", since "+" is
+// not allowed in tags.
+func VersionTag(version string) (string, error) {
+	normalized, err := NormalizeVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	return "v" + strings.ReplaceAll(normalized, "+", "_"), nil
+}
+
+// ValidateSpecVersion checks that v is a supported spec format version.
+func ValidateSpecVersion(v string) error {
+	for _, supported := range common.SupportedSpecVersions {
+		if v == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported spec version %q, expected one of %s", v, strings.Join(common.SupportedSpecVersions, ", "))
+}
+
+// ResolveVersion returns the normalized runtime version of an artifact built
+// from specContent. It is taken from override if set, otherwise from the
+// version field of the spec; if both are set they must agree. It returns an
+// empty string if neither is set or specContent is not a runtime spec.
+func ResolveVersion(specContent []byte, override string) (string, error) {
+	var declared string
+	var runtimeSpec common.RuntimeSpec
+	if err := yaml.Unmarshal(specContent, &runtimeSpec); err == nil && runtimeSpec.Version != "" {
+		normalized, err := NormalizeVersion(runtimeSpec.Version)
+		if err != nil {
+			return "", fmt.Errorf("invalid runtime version in spec: %w", err)
+		}
+		declared = normalized
+	}
+
+	if override == "" {
+		return declared, nil
+	}
+
+	version, err := NormalizeVersion(override)
+	if err != nil {
+		return "", fmt.Errorf("invalid runtime version: %w", err)
+	}
+	if declared != "" && declared != version {
+		return "", fmt.Errorf("runtime version %s does not match the version %s declared in the spec", version, declared)
+	}
+
+	return version, nil
+}
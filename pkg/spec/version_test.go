@@ -0,0 +1,92 @@
+package spec
+
+import "testing"
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+		tag      string
+		wantErr  bool
+	}{
+		{version: "1.2.3", expected: "1.2.3", tag: "v1.2.3"},
+		{version: "v1.2.3", expected: "1.2.3", tag: "v1.2.3"},
+		{version: "v1.2", expected: "1.2.0", tag: "v1.2.0"},
+		{version: "2", expected: "2.0.0", tag: "v2.0.0"},
+		{version: "1.0.0-rc.1", expected: "1.0.0-rc.1", tag: "v1.0.0-rc.1"},
+		{version: "1.0.0+build.5", expected: "1.0.0+build.5", tag: "v1.0.0_build.5"},
+		{version: "", wantErr: true},
+		{version: "latest", wantErr: true},
+		{version: "1.2.3.4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			normalized, err := NormalizeVersion(tt.version)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected %q to be rejected, got %s", tt.version, normalized)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to normalize %q: %v", tt.version, err)
+			}
+			if normalized != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, normalized)
+			}
+
+			tag, err := VersionTag(tt.version)
+			if err != nil || tag != tt.tag {
+				t.Errorf("Expected tag %s, got %s (%v)", tt.tag, tag, err)
+			}
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	specContent := []byte("apiVersion: eigenruntime.io/v1alpha1\nkind: Runtime\nname: example\nversion: v1.2.0\n")
+
+	tests := []struct {
+		name     string
+		content  []byte
+		override string
+		expected string
+		wantErr  bool
+	}{
+		{name: "from spec", content: specContent, expected: "1.2.0"},
+		{name: "matching override", content: specContent, override: "1.2", expected: "1.2.0"},
+		{name: "conflicting override", content: specContent, override: "1.3.0", wantErr: true},
+		{name: "override without spec version", content: []byte("name: example\n"), override: "v2", expected: "2.0.0"},
+		{name: "not a spec", content: []byte("test"), expected: ""},
+		{name: "invalid runtime version in spec", content: []byte("version: latest\n"), wantErr: true},
+		{name: "invalid override", content: []byte("name: example\n"), override: "latest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ResolveVersion(tt.content, tt.override)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %q", version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if version != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, version)
+			}
+		})
+	}
+}
+
+func TestValidateSpecVersion(t *testing.T) {
+	if err := ValidateSpecVersion("v1"); err != nil {
+		t.Errorf("Expected v1 to be supported: %v", err)
+	}
+	if err := ValidateSpecVersion("v2"); err == nil {
+		t.Error("Expected v2 to be rejected")
+	}
+}
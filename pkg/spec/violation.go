@@ -0,0 +1,58 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Violation is a single problem found in a spec, whether by validation, an
+// admission policy or an upgrade policy. Path locates the offending field,
+// e.g. "spec.performer.registry", and is empty for problems with the spec as
+// a whole. Rule names the policy rule that was broken and is empty for
+// built-in validation.
+type Violation struct {
+	Rule    string `json:"rule,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	s := v.Message
+	if v.Path != "" {
+		s = v.Path + ": " + s
+	}
+	if v.Rule != "" {
+		s += " (" + v.Rule + ")"
+	}
+	return s
+}
+
+// ValidationError is returned when a spec fails validation or an admission
+// policy. It lists every violation found, not just the first.
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid spec: " + joinViolations(e.Violations)
+}
+
+// validationError returns a *ValidationError for violations, or nil if there
+// are none.
+func validationError(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
+
+func joinViolations(violations []Violation) string {
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.String()
+	}
+	if len(msgs) == 1 {
+		return msgs[0]
+	}
+	return fmt.Sprintf("%d problems: %s", len(msgs), strings.Join(msgs, "; "))
+}